@@ -0,0 +1,118 @@
+package swag
+
+import (
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+)
+
+// newBuildContext returns the context the build constraints of the files are evaluated in: the default one
+// for the build tags, GOOS and GOARCH, which default to the ones of the go command.
+func newBuildContext(tags []string, goos, goarch string) build.Context {
+	buildContext := build.Default
+	buildContext.BuildTags = tags
+
+	if goos != "" {
+		buildContext.GOOS = goos
+	}
+
+	if goarch != "" {
+		buildContext.GOARCH = goarch
+	}
+
+	return buildContext
+}
+
+// buildEnv returns the environment variables the packages are loaded with for the target.
+func (parser *Parser) buildEnv() []string {
+	var env []string
+
+	if parser.goos != "" {
+		env = append(env, "GOOS="+parser.goos)
+	}
+
+	if parser.goarch != "" {
+		env = append(env, "GOARCH="+parser.goarch)
+	}
+
+	return env
+}
+
+// matchFile reports whether the file of path satisfies its build constraints, from its //go:build lines
+// and its _GOOS_GOARCH name suffix.
+func (parser *Parser) matchFile(path string) (bool, error) {
+	return parser.buildContext.MatchFile(filepath.Dir(path), filepath.Base(path))
+}
+
+// reportConstrainedTypes warns about the types declared by both the skipped files and the parsed files
+// of their package, which collide in the document unless the build constraints are evaluated, so the
+// document depends on the build tags and the target.
+func (parser *Parser) reportConstrainedTypes(skipped []goFile) {
+	parsedTypes := make(map[string]map[string]string)
+
+	for _, file := range skipped {
+		parsedTypes[file.packageDir] = nil
+	}
+
+	for astFile, info := range parser.packages.files {
+		types, ok := parsedTypes[info.PackagePath]
+		if !ok {
+			continue
+		}
+
+		if types == nil {
+			types = make(map[string]string)
+			parsedTypes[info.PackagePath] = types
+		}
+
+		for _, name := range declaredTypes(astFile) {
+			if path, ok := types[name]; !ok || info.Path < path {
+				types[name] = info.Path
+			}
+		}
+	}
+
+	for _, file := range skipped {
+		astFile, err := goparser.ParseFile(token.NewFileSet(), file.path, nil, goparser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+
+		skippedPath, err := filepath.Abs(file.path)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range declaredTypes(astFile) {
+			if path, ok := parsedTypes[file.packageDir][name]; ok {
+				parser.debug.Printf("warning: type %s.%s is declared in %s and in %s, which is skipped by its build constraints",
+					file.packageDir, name, path, skippedPath)
+			}
+		}
+	}
+}
+
+// declaredTypes returns the sorted names of the types declared at the top level of astFile.
+func declaredTypes(astFile *ast.File) []string {
+	var names []string
+
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
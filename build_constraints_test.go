@@ -0,0 +1,69 @@
+package swag
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuildConstraints(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/build_constraints"
+
+	properties := func(schema spec.Schema) []string {
+		var names []string
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+
+		return names
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		logger := &testLogger{}
+
+		p := New(SetDebugger(logger), SetBuildTarget("linux", "amd64"))
+		require.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+		assert.Contains(t, p.swagger.Paths.Paths, "/users")
+		assert.NotContains(t, p.swagger.Paths.Paths, "/license")
+		assert.ElementsMatch(t, []string{"name"}, properties(p.swagger.Definitions["main.User"]))
+		assert.ElementsMatch(t, []string{"home"}, properties(p.swagger.Definitions["main.Settings"]))
+
+		pkgPath := "github.com/swaggo/swag/testdata/build_constraints"
+
+		assert.Contains(t, logger.Messages, "warning: type "+pkgPath+".Settings is declared in "+
+			absPath(t, searchDir, "settings_linux.go")+" and in "+absPath(t, searchDir, "settings_windows.go")+
+			", which is skipped by its build constraints")
+		assert.Contains(t, logger.Messages, "warning: type "+pkgPath+".User is declared in "+
+			absPath(t, searchDir, "user.go")+" and in "+absPath(t, searchDir, "user_enterprise.go")+
+			", which is skipped by its build constraints")
+	})
+
+	t.Run("tags and target", func(t *testing.T) {
+		t.Parallel()
+
+		p := New(SetBuildTags([]string{"enterprise"}), SetBuildTarget("windows", "amd64"))
+		require.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+		assert.Contains(t, p.swagger.Paths.Paths, "/users")
+		assert.Contains(t, p.swagger.Paths.Paths, "/license")
+		assert.ElementsMatch(t, []string{"name", "seats"}, properties(p.swagger.Definitions["main.User"]))
+		assert.ElementsMatch(t, []string{"drive"}, properties(p.swagger.Definitions["main.Settings"]))
+	})
+}
+
+func absPath(t *testing.T, elem ...string) string {
+	t.Helper()
+
+	path, err := filepath.Abs(filepath.Join(elem...))
+	require.NoError(t, err)
+
+	return path
+}
@@ -0,0 +1,110 @@
+package swag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// sinceExtension the version an operation or a field appeared in, set by @Since.
+	sinceExtension = "x-since"
+
+	// changesExtension the versions an operation or a field changed in with a description of the change,
+	// set by @Changed, eg: [{"version": "v2.0", "description": "paginated"}].
+	changesExtension = "x-changes"
+)
+
+// ParseSinceComment parses comment for given `since` comment string, eg: @Since v1.4.
+func (operation *Operation) ParseSinceComment(commentLine string) error {
+	version, err := parseSince(commentLine)
+	if err != nil {
+		return err
+	}
+
+	operation.Operation.AddExtension(sinceExtension, version)
+
+	return nil
+}
+
+// ParseChangedComment parses comment for given `changed` comment string, eg: @Changed v2.0 "paginated".
+func (operation *Operation) ParseChangedComment(commentLine string) error {
+	change, err := parseChanged(commentLine)
+	if err != nil {
+		return err
+	}
+
+	if operation.Extensions == nil {
+		operation.Extensions = make(spec.Extensions)
+	}
+
+	changes, _ := operation.Extensions[changesExtension].([]interface{})
+	operation.Extensions[changesExtension] = append(changes, change)
+
+	return nil
+}
+
+func parseSince(commentLine string) (string, error) {
+	fields := FieldsByAnySpace(strings.TrimSpace(commentLine), -1)
+	if len(fields) != 1 {
+		return "", fmt.Errorf("@Since needs a version, eg: @Since v1.4, got: %s", commentLine)
+	}
+
+	return fields[0], nil
+}
+
+func parseChanged(commentLine string) (map[string]interface{}, error) {
+	fields := FieldsByAnySpace(strings.TrimSpace(commentLine), 2)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf(`@Changed needs a version and a description, eg: @Changed v2.0 "paginated", got: %s`, commentLine)
+	}
+
+	description := strings.TrimSpace(fields[1])
+	if unquoted, err := strconv.Unquote(description); err == nil {
+		description = unquoted
+	}
+
+	return map[string]interface{}{
+		"version":     fields[0],
+		"description": description,
+	}, nil
+}
+
+// parseFieldVersions removes the @Since and @Changed lines of the comment text of a field, and adds them
+// to extensions.
+func parseFieldVersions(text string, extensions spec.Extensions) (string, error) {
+	var lines []string
+
+	for _, line := range strings.Split(text, "\n") {
+		fields := FieldsByAnySpace(strings.TrimSpace(line), 2)
+
+		attribute := ""
+		if len(fields) > 0 {
+			attribute = strings.ToLower(fields[0])
+		}
+
+		switch attribute {
+		case sinceAttr:
+			version, err := parseSince(strings.Join(fields[1:], " "))
+			if err != nil {
+				return "", err
+			}
+
+			extensions[sinceExtension] = version
+		case changedAttr:
+			change, err := parseChanged(strings.Join(fields[1:], " "))
+			if err != nil {
+				return "", err
+			}
+
+			changes, _ := extensions[changesExtension].([]interface{})
+			extensions[changesExtension] = append(changes, change)
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
@@ -0,0 +1,84 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinceAndChangedComments(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Since v1.4`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Changed v2.0 "paginated, see the Link header"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Changed v2.1 sorted by name`, nil))
+
+	assert.Equal(t, "v1.4", operation.Extensions[sinceExtension])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"version": "v2.0", "description": "paginated, see the Link header"},
+		map[string]interface{}{"version": "v2.1", "description": "sorted by name"},
+	}, operation.Extensions[changesExtension])
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Since`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Since v1 v2`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Changed v2.0`, nil))
+}
+
+func TestParseFieldVersions(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	// Name of the pet.
+	// @Changed v2.0 "up to 64 characters"
+	Name string ` + "`json:\"name\"`" + `
+
+	// Owner of the pet.
+	// @Since v1.4
+	Owner *Owner ` + "`json:\"owner\"`" + `
+
+	Tag string // @Since v1.2
+}
+
+type Owner struct {
+	ID int
+}
+
+// @Since v1.0
+// @Success 200 {object} Pet
+// @Router /pets [get]
+func ListPets(){
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	assert.Equal(t, "v1.0", p.swagger.Paths.Paths["/pets"].Get.Extensions[sinceExtension])
+
+	pet := p.swagger.Definitions["api.Pet"]
+
+	name := pet.Properties["name"]
+	assert.Equal(t, "Name of the pet.", name.Description)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"version": "v2.0", "description": "up to 64 characters"},
+	}, name.Extensions[changesExtension])
+
+	// the extensions of a reference go along its description
+	owner := pet.Properties["owner"]
+	assert.Equal(t, "Owner of the pet.", owner.Description)
+	assert.Equal(t, "v1.4", owner.Extensions[sinceExtension])
+	assert.Len(t, owner.AllOf, 1)
+
+	tag := pet.Properties["tag"]
+	assert.Empty(t, tag.Description)
+	assert.Equal(t, "v1.2", tag.Extensions[sinceExtension])
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/urfave/cli/v2"
+	"sigs.k8s.io/yaml"
+
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+const (
+	baseFlag           = "base"
+	reportFlag         = "report"
+	failOnBreakingFlag = "failOnBreaking"
+)
+
+var diffFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:  baseFlag,
+		Usage: "Git ref to regenerate the old document from in a temporary worktree, the new one is regenerated from the working tree with the init flags",
+	},
+	&cli.StringFlag{
+		Name:  reportFlag,
+		Value: "text",
+		Usage: "Format of the report, text or json",
+	},
+	&cli.BoolFlag{
+		Name:  failOnBreakingFlag,
+		Usage: "Exit with a non-zero status if there are breaking changes (default: false)",
+	},
+}, initFlags...)
+
+func diffAction(ctx *cli.Context) error {
+	var base, revision *spec.Swagger
+
+	if ref := ctx.String(baseFlag); ref != "" {
+		if ctx.NArg() != 0 {
+			return fmt.Errorf("diff takes no documents with --%s, they are regenerated", baseFlag)
+		}
+
+		config, err := initConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		revision, err = generateSwagger(config)
+		if err != nil {
+			return err
+		}
+
+		base, err = generateSwaggerAt(ref, config)
+		if err != nil {
+			return err
+		}
+	} else {
+		if ctx.NArg() != 2 {
+			return fmt.Errorf("diff needs the old and the new swagger documents, or --%s", baseFlag)
+		}
+
+		var err error
+
+		base, err = loadSwagger(ctx.Args().Get(0))
+		if err != nil {
+			return err
+		}
+
+		revision, err = loadSwagger(ctx.Args().Get(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	report := swag.DiffSwagger(base, revision)
+
+	switch ctx.String(reportFlag) {
+	case "json":
+		b, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+		if err != nil {
+			return err
+		}
+	case "text":
+		err := writeDiffReport(os.Stdout, report)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("not supported %s report, use text or json", ctx.String(reportFlag))
+	}
+
+	if breaking := report.Breaking(); ctx.Bool(failOnBreakingFlag) && len(breaking) > 0 {
+		return fmt.Errorf("%d breaking changes", len(breaking))
+	}
+
+	return nil
+}
+
+// writeDiffReport writes report as a line by change.
+func writeDiffReport(w io.Writer, report *swag.DiffReport) error {
+	for _, change := range report.Changes {
+		label := "info"
+
+		switch {
+		case change.Breaking:
+			label = "breaking"
+		case change.Deprecated:
+			label = "deprecated"
+		}
+
+		subject := strings.TrimSpace(change.Method + " " + change.Path)
+		if change.Location != "" {
+			subject = strings.TrimSpace(subject + " " + change.Location)
+		}
+
+		if subject != "" {
+			subject += ": "
+		}
+
+		_, err := fmt.Fprintf(w, "[%s] %s%s\n", label, subject, change.Message)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d changes, %d breaking\n", len(report.Changes), len(report.Breaking()))
+
+	return err
+}
+
+func loadSwagger(file string) (*spec.Swagger, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if ext := filepath.Ext(file); ext == ".yaml" || ext == ".yml" {
+		b, err = yaml.YAMLToJSON(b)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+	}
+
+	var swagger spec.Swagger
+
+	err = json.Unmarshal(b, &swagger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+	}
+
+	return &swagger, nil
+}
+
+// generateSwagger generates the swagger document of config in a temporary directory and loads it.
+func generateSwagger(config *gen.Config) (*spec.Swagger, error) {
+	outputDir, err := os.MkdirTemp("", "swag-diff")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(outputDir)
+
+	generateConfig := *config
+	generateConfig.OutputDir = outputDir
+	generateConfig.OutputTypes = []string{"json"}
+	generateConfig.Visibilities = nil
+	generateConfig.Versions = nil
+	generateConfig.SplitDefinitions = ""
+	generateConfig.Check = false
+
+	err = gen.New().Build(&generateConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(outputDir, "*swagger.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) != 1 {
+		return nil, fmt.Errorf("cannot find the generated swagger.json in %s", outputDir)
+	}
+
+	return loadSwagger(files[0])
+}
+
+// generateSwaggerAt generates the swagger document of config from the git ref, checked out in a temporary worktree,
+// in the directory matching the current one.
+func generateSwaggerAt(ref string, config *gen.Config) (*spec.Swagger, error) {
+	prefix, err := git("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	worktree, err := os.MkdirTemp("", "swag-diff-base")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(worktree)
+
+	_, err = git("worktree", "add", "--detach", worktree, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_, _ = git("worktree", "remove", "--force", worktree)
+	}()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	// the paths of the flags are relative to the current directory
+	err = os.Chdir(filepath.Join(worktree, filepath.FromSlash(prefix)))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = os.Chdir(dir)
+	}()
+
+	return generateSwagger(config)
+}
+
+func git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
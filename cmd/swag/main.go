@@ -5,7 +5,9 @@ import (
 	"io"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -34,6 +36,11 @@ const (
 	instanceNameFlag         = "instanceName"
 	overridesFileFlag        = "overridesFile"
 	parseGoListFlag          = "parseGoList"
+	buildTagsFlag            = "buildTags"
+	goosFlag                 = "goos"
+	goarchFlag               = "goarch"
+	parseCacheFlag           = "parseCache"
+	jobsFlag                 = "jobs"
 	quietFlag                = "quiet"
 	tagsFlag                 = "tags"
 	parseExtensionFlag       = "parseExtension"
@@ -43,6 +50,19 @@ const (
 	packagePrefixFlag        = "packagePrefix"
 	stateFlag                = "state"
 	parseFuncBodyFlag        = "parseFuncBody"
+	visibilityFlag           = "visibility"
+	pruneDefinitionsFlag     = "pruneDefinitions"
+	keepDefinitionsFlag      = "keepDefinitions"
+	markdownTemplateFlag     = "markdownTemplate"
+	htmlTemplateFlag         = "htmlTemplate"
+	jsonSchemaTypesFlag      = "jsonSchemaTypes"
+	jsonSchemaBundleFlag     = "jsonSchemaBundle"
+	jsonSchemaIDFlag         = "jsonSchemaId"
+	splitDefinitionsFlag     = "splitDefinitions"
+	checkFlag                = "check"
+	versionsFlag             = "versions"
+	watchFlag                = "watch"
+	inputFlag                = "input"
 )
 
 var initFlags = []cli.Flag{
@@ -83,7 +103,7 @@ var initFlags = []cli.Flag{
 		Name:    outputTypesFlag,
 		Aliases: []string{"ot"},
 		Value:   "go,json,yaml",
-		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml) like go,json,yaml",
+		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml, postman_collection.json, <tag>.http, api.md, api.html, client/client.go, api.ts, schemas/*.schema.json, changelog.md) like go,json,yaml,postman,http,markdown,html,goclient,ts,jsonschema,changelog",
 	},
 	&cli.BoolFlag{
 		Name:  parseVendorFlag,
@@ -141,7 +161,32 @@ var initFlags = []cli.Flag{
 	&cli.BoolFlag{
 		Name:  parseGoListFlag,
 		Value: true,
-		Usage: "Parse dependency via 'go list'",
+		Usage: "Deprecated: the dependencies are always loaded with go/packages, the way the go command resolves them",
+	},
+	&cli.StringFlag{
+		Name:  buildTagsFlag,
+		Value: "",
+		Usage: "A comma-separated list of build tags the //go:build constraints of the files are evaluated with, separate from --tags",
+	},
+	&cli.StringFlag{
+		Name:  goosFlag,
+		Value: "",
+		Usage: "Target operating system the build constraints of the files are evaluated for, defaults to GOOS",
+	},
+	&cli.StringFlag{
+		Name:  goarchFlag,
+		Value: "",
+		Usage: "Target architecture the build constraints of the files are evaluated for, defaults to GOARCH",
+	},
+	&cli.StringFlag{
+		Name:  parseCacheFlag,
+		Value: "",
+		Usage: "Directory where the sources of the parsed files, stripped of what swag doesn't read, are cached across runs, by content and swag version, the entries unused for 5 days are removed, eg: ~/.cache/swag",
+	},
+	&cli.IntFlag{
+		Name:  jobsFlag,
+		Value: 0,
+		Usage: "Number of files parsed concurrently, 0 for as many as CPUs",
 	},
 	&cli.StringFlag{
 		Name:  parseExtensionFlag,
@@ -186,15 +231,94 @@ var initFlags = []cli.Flag{
 		// Value: false,
 		Usage: "Parse API info within body of functions in go files, disabled by default (default: false)",
 	},
+	&cli.StringFlag{
+		Name:  visibilityFlag,
+		Value: "",
+		Usage: "A comma-separated list of visibilities (public, partner, internal) to generate an additional filtered document for, named <instanceName>_<visibility>",
+	},
+	&cli.BoolFlag{
+		Name:  pruneDefinitionsFlag,
+		Usage: "Remove the definitions not referenced by any operation, and print why the remaining ones are kept",
+	},
+	&cli.StringFlag{
+		Name:  keepDefinitionsFlag,
+		Value: "",
+		Usage: "A comma-separated list of definition name patterns to keep when pruning definitions, eg: model.*",
+	},
+	&cli.StringFlag{
+		Name:  markdownTemplateFlag,
+		Value: "",
+		Usage: "Go template file replacing the default layout of the markdown output type",
+	},
+	&cli.StringFlag{
+		Name:  htmlTemplateFlag,
+		Value: "",
+		Usage: "Go template file replacing the default layout of the html output type",
+	},
+	&cli.StringFlag{
+		Name:  jsonSchemaTypesFlag,
+		Value: "",
+		Usage: "A comma-separated list of types to export with the jsonschema output type besides the ones annotated with @JSONSchema, eg: model.Account,github.com/example/model.Event",
+	},
+	&cli.BoolFlag{
+		Name:  jsonSchemaBundleFlag,
+		Usage: "Write the JSON Schemas as one schema.json bundle with $defs instead of a .schema.json file per definition",
+	},
+	&cli.StringFlag{
+		Name:  jsonSchemaIDFlag,
+		Value: "",
+		Usage: "Base URI of the $id of the JSON Schemas, eg: https://example.com/schemas/",
+	},
+	&cli.StringFlag{
+		Name:  splitDefinitionsFlag,
+		Value: "",
+		Usage: "Write the definitions of swagger.json and swagger.yaml into separate files of the definitions directory, by package or by type",
+	},
+	&cli.StringFlag{
+		Name:  versionsFlag,
+		Value: "",
+		Usage: "A comma-separated list of API versions to generate a document for, with the operations annotated with @Version for them, and an optional base path, eg: v1=/api/v1,v2=/api/v2",
+	},
+	&cli.BoolFlag{
+		Name:  checkFlag,
+		Usage: "Regenerate the files in memory, print how they differ from the files on disk and fail if they do, without writing them",
+	},
+	&cli.BoolFlag{
+		Name:  watchFlag,
+		Usage: "Keep running, build the part of the document depending on the changed Go files of the search dirs again and regenerate the files when the document changed",
+	},
 }
 
 func initAction(ctx *cli.Context) error {
+	config, err := initConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(watchFlag) {
+		stop := make(chan struct{})
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt)
+
+		go func() {
+			<-signals
+			close(stop)
+		}()
+
+		return gen.New().Watch(config, time.Second, stop)
+	}
+
+	return gen.New().Build(config)
+}
+
+// initConfig returns the gen configuration of the init flags.
+func initConfig(ctx *cli.Context) (*gen.Config, error) {
 	strategy := ctx.String(propertyStrategyFlag)
 
 	switch strategy {
 	case swag.CamelCase, swag.SnakeCase, swag.PascalCase:
 	default:
-		return fmt.Errorf("not supported %s propertyStrategy", strategy)
+		return nil, fmt.Errorf("not supported %s propertyStrategy", strategy)
 	}
 
 	leftDelim, rightDelim := "{{", "}}"
@@ -202,11 +326,11 @@ func initAction(ctx *cli.Context) error {
 	if ctx.IsSet(templateDelimsFlag) {
 		delims := strings.Split(ctx.String(templateDelimsFlag), ",")
 		if len(delims) != 2 {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"exactly two template delimiters must be provided, comma separated",
 			)
 		} else if delims[0] == delims[1] {
-			return fmt.Errorf("template delimiters must be different")
+			return nil, fmt.Errorf("template delimiters must be different")
 		}
 		leftDelim, rightDelim = strings.TrimSpace(
 			delims[0],
@@ -217,7 +341,7 @@ func initAction(ctx *cli.Context) error {
 
 	outputTypes := strings.Split(ctx.String(outputTypesFlag), ",")
 	if len(outputTypes) == 0 {
-		return fmt.Errorf("no output types specified")
+		return nil, fmt.Errorf("no output types specified")
 	}
 	logger := log.New(os.Stdout, "", log.LstdFlags)
 	if ctx.Bool(quietFlag) {
@@ -228,19 +352,39 @@ func initAction(ctx *cli.Context) error {
 		ctx.String(collectionFormatFlag),
 	)
 	if collectionFormat == "" {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"not supported %s collectionFormat",
 			ctx.String(collectionFormat),
 		)
 	}
 
+	var visibilities []string
+	if ctx.String(visibilityFlag) != "" {
+		visibilities = strings.Split(ctx.String(visibilityFlag), ",")
+	}
+
+	var keepDefinitions []string
+	if ctx.String(keepDefinitionsFlag) != "" {
+		keepDefinitions = strings.Split(ctx.String(keepDefinitionsFlag), ",")
+	}
+
+	var versions []string
+	if ctx.String(versionsFlag) != "" {
+		versions = strings.Split(ctx.String(versionsFlag), ",")
+	}
+
+	var jsonSchemaTypes []string
+	if ctx.String(jsonSchemaTypesFlag) != "" {
+		jsonSchemaTypes = strings.Split(ctx.String(jsonSchemaTypesFlag), ",")
+	}
+
 	var pdv = ctx.Int(parseDependencyLevelFlag)
 	if pdv == 0 {
 		if ctx.Bool(parseDependencyFlag) {
 			pdv = 1
 		}
 	}
-	return gen.New().Build(&gen.Config{
+	return &gen.Config{
 		SearchDir:           ctx.String(searchDirFlag),
 		Excludes:            ctx.String(excludeFlag),
 		ParseExtension:      ctx.String(parseExtensionFlag),
@@ -259,6 +403,11 @@ func initAction(ctx *cli.Context) error {
 		InstanceName:        ctx.String(instanceNameFlag),
 		OverridesFile:       ctx.String(overridesFileFlag),
 		ParseGoList:         ctx.Bool(parseGoListFlag),
+		BuildTags:           ctx.String(buildTagsFlag),
+		GOOS:                ctx.String(goosFlag),
+		GOARCH:              ctx.String(goarchFlag),
+		ParseCacheDir:       ctx.String(parseCacheFlag),
+		Jobs:                ctx.Int(jobsFlag),
 		Tags:                ctx.String(tagsFlag),
 		LeftTemplateDelim:   leftDelim,
 		RightTemplateDelim:  rightDelim,
@@ -268,7 +417,18 @@ func initAction(ctx *cli.Context) error {
 		PackagePrefix:       ctx.String(packagePrefixFlag),
 		State:               ctx.String(stateFlag),
 		ParseFuncBody:       ctx.Bool(parseFuncBodyFlag),
-	})
+		Visibilities:        visibilities,
+		PruneDefinitions:    ctx.Bool(pruneDefinitionsFlag),
+		KeepDefinitions:     keepDefinitions,
+		MarkdownTemplate:    ctx.String(markdownTemplateFlag),
+		HTMLTemplate:        ctx.String(htmlTemplateFlag),
+		JSONSchemaTypes:     jsonSchemaTypes,
+		JSONSchemaBundle:    ctx.Bool(jsonSchemaBundleFlag),
+		JSONSchemaID:        ctx.String(jsonSchemaIDFlag),
+		SplitDefinitions:    ctx.String(splitDefinitionsFlag),
+		Check:               ctx.Bool(checkFlag),
+		Versions:            versions,
+	}, nil
 }
 
 func main() {
@@ -328,6 +488,38 @@ func main() {
 				},
 			},
 		},
+		{
+			Name:      "diff",
+			Aliases:   []string{"d"},
+			Usage:     "classify the changes between two swagger documents as breaking or not",
+			ArgsUsage: "[old.json new.json]",
+			Action:    diffAction,
+			Flags:     diffFlags,
+		},
+		{
+			Name:    "bundle",
+			Aliases: []string{"b"},
+			Usage:   "inline the split definitions of a swagger document",
+			Action: func(c *cli.Context) error {
+				return gen.New().Bundle(&gen.BundleConfig{
+					Input:  c.String(inputFlag),
+					Output: c.String(outputFlag),
+				})
+			},
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    inputFlag,
+					Aliases: []string{"i"},
+					Value:   "./docs/swagger.json",
+					Usage:   "Root document of the swagger document written with split definitions",
+				},
+				&cli.StringFlag{
+					Name:    outputFlag,
+					Aliases: []string{"o"},
+					Usage:   "Bundled document, json or yaml by its extension, replaces the input if empty",
+				},
+			},
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -14,6 +14,7 @@ const (
 	summaryPrefix = "//@Summary"
 	acceptPrefix  = "//@Accept application/json"
 	producePrefix = "//@Produce application/json"
+	streamPrefix  = "//@Stream"
 )
 
 func (req ReqParam) GetSwagComment() string {
@@ -34,10 +35,35 @@ type Resp struct {
 	RespType    string
 }
 
+// StreamResp 流式响应，c.SSEvent 为 sse，c.Stream 中通过 json.Encoder 写入为 ndjson
+type StreamResp struct {
+	Format   string
+	Events   []*StreamEvent
+	ItemType string
+}
+
+// StreamEvent c.SSEvent 的事件名和数据类型
+type StreamEvent struct {
+	Name string
+	Type string
+}
+
+func (s *StreamResp) GetSwagComment() string {
+	if s.Format == streamFormatNDJSON {
+		return fmt.Sprintf("%s %d %s %s %s", streamPrefix, 200, s.Format, s.ItemType, "\"成功\"")
+	}
+	events := make([]string, 0, len(s.Events))
+	for _, v := range s.Events {
+		events = append(events, v.Name+"="+v.Type)
+	}
+	return fmt.Sprintf("%s %d %s %s %s", streamPrefix, 200, s.Format, strings.Join(events, ","), "\"成功\"")
+}
+
 type FuncDetail struct {
 	FuncName string
 	ReqParam []*ReqParam
 	Resp     *Resp
+	Stream   *StreamResp
 	Comment  string
 	Router   string
 }
@@ -54,7 +80,10 @@ func (f *FuncDetail) BuildComment() []*ast.Comment {
 		//log.Println(v.GetSwagComment())
 		comments = append(comments, c)
 	}
-	if f.Resp.RespVarType != "" {
+	if f.Stream != nil {
+		stream := &ast.Comment{Text: f.Stream.GetSwagComment()}
+		comments = append(comments, stream)
+	} else if f.Resp.RespVarType != "" {
 		resp := &ast.Comment{Text: f.Resp.GetSwagComment()}
 		comments = append(comments, resp)
 	}
@@ -77,10 +106,11 @@ func processPackage(pkg *packages.Package) ([]*FileDetail, map[string]*RouterInf
 		routerInfo = map[string]*RouterInfo{}
 	)
 	//pkg.Syntax 为文件整个节点
-	for i, file := range pkg.Syntax {
+	for _, file := range pkg.Syntax {
 		fd := &FileDetail{}
 		ast.Inspect(file, func(n ast.Node) bool {
-			fd.Filename = pkg.GoFiles[i]
+			// the syntax of the cgo files is the one generated by cgo, with line directives to the original files
+			fd.Filename = pkg.Fset.Position(file.Package).Filename
 			fd.PkgPath = pkg.PkgPath
 			switch node := n.(type) {
 			case *ast.FuncDecl:
@@ -120,6 +150,7 @@ func processFunction(pkg *packages.Package, fn *ast.FuncDecl) *FuncDetail {
 				RespVarType: resp,
 				RespType:    respType,
 			},
+			Stream:   parseStreamDetails(pkg, fn),
 			Comment:  comment,
 			FuncName: fn.Name.Name,
 		}
@@ -202,6 +233,63 @@ func parseHandlerDetails(pkg *packages.Package, fn *ast.FuncDecl) (req []*ReqPar
 	return
 }
 
+const (
+	streamFormatSSE    = "sse"
+	streamFormatNDJSON = "ndjson"
+)
+
+// parseStreamDetails 解析 c.SSEvent 和 c.Stream 调用，不是流式响应返回 nil
+func parseStreamDetails(pkg *packages.Package, fn *ast.FuncDecl) *StreamResp {
+	var (
+		events   []*StreamEvent
+		seen     = map[string]bool{}
+		isStream bool
+		itemType string
+	)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		switch getTypeName(pkg, sel.X) {
+		case "gin.Context":
+			switch sel.Sel.Name {
+			case "SSEvent":
+				// c.SSEvent("message", msg) 第一个参数为事件名，第二个参数为数据
+				if len(call.Args) != 2 {
+					return true
+				}
+				name := extractStringLiteral(call.Args[0])
+				if name == "" || seen[name] {
+					return true
+				}
+				seen[name] = true
+				events = append(events, &StreamEvent{Name: name, Type: getTypeName(pkg, call.Args[1])})
+			case "Stream":
+				isStream = true
+			}
+		case "json.Encoder":
+			// c.Stream 中 json.NewEncoder(w).Encode(row) 写入一行
+			if sel.Sel.Name == "Encode" && len(call.Args) == 1 && itemType == "" {
+				itemType = getTypeName(pkg, call.Args[0])
+			}
+		}
+		return true
+	})
+
+	switch {
+	case len(events) > 0:
+		return &StreamResp{Format: streamFormatSSE, Events: events}
+	case isStream && itemType != "":
+		return &StreamResp{Format: streamFormatNDJSON, ItemType: itemType}
+	}
+	return nil
+}
+
 const (
 	shouldBindPrefix = "ShouldBind"
 	bindPrefix       = "Bind"
@@ -2,22 +2,10 @@ package gin
 
 import (
 	"golang.org/x/tools/go/packages"
-	"log"
 )
 
-func ParseDetail(dir string) []*FileDetail {
-
-	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles |
-			packages.NeedSyntax | packages.NeedTypes |
-			packages.NeedTypesInfo | packages.NeedImports,
-		Dir: dir,
-	}
-	pkgs, err := packages.Load(cfg, "./...")
-	if err != nil {
-		log.Fatal("包加载失败:", err)
-	}
-
+// ParseDetail returns the details of the gin handlers of pkgs, loaded with their syntax and types.
+func ParseDetail(pkgs []*packages.Package) []*FileDetail {
 	var (
 		fdList     []*FileDetail
 		routerInfo = map[string]*RouterInfo{}
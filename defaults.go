@@ -0,0 +1,203 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// operationDefaults holds the annotations of a `@swag.defaults` comment block.
+// They are merged into every operation of the package the block is declared in,
+// or, if pathPrefix is set, into every operation whose route starts with it.
+type operationDefaults struct {
+	pkgPath    string
+	pathPrefix string
+	operation  *Operation
+}
+
+// matches reports whether the defaults apply to operation declared in pkgPath.
+func (defaults *operationDefaults) matches(operation *Operation, pkgPath string) bool {
+	if defaults.pathPrefix == "" {
+		return defaults.pkgPath == pkgPath
+	}
+
+	prefix := strings.TrimSuffix(defaults.pathPrefix, "/")
+	for _, route := range operation.RouterProperties {
+		if route.Path == prefix || strings.HasPrefix(route.Path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findOperationDefaultsLine returns the index of the `@swag.defaults` line in comments
+// and the path prefix following it, or -1 if comments are not a defaults block.
+func findOperationDefaultsLine(comments []*ast.Comment) (int, string) {
+	for i, comment := range comments {
+		commentLine := strings.TrimSpace(strings.TrimLeft(comment.Text, "/"))
+		if len(commentLine) == 0 {
+			continue
+		}
+
+		fields := FieldsByAnySpace(commentLine, 2)
+		if strings.ToLower(fields[0]) != defaultsAttr {
+			continue
+		}
+
+		if len(fields) > 1 {
+			return i, strings.TrimSpace(fields[1])
+		}
+
+		return i, ""
+	}
+
+	return -1, ""
+}
+
+func isOperationDefaultsComment(comments []*ast.Comment) bool {
+	index, _ := findOperationDefaultsLine(comments)
+
+	return index >= 0
+}
+
+// parseOperationDefaults collects the `@swag.defaults` blocks declared in the comments of given file.
+func (parser *Parser) parseOperationDefaults(fileInfo *AstFileInfo) error {
+	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
+		return nil
+	}
+
+	for _, commentGroup := range fileInfo.File.Comments {
+		index, pathPrefix := findOperationDefaultsLine(commentGroup.List)
+		if index < 0 {
+			continue
+		}
+
+		if pathPrefix != "" && !strings.HasPrefix(pathPrefix, "/") {
+			return fmt.Errorf("%s in file %s: path prefix %q must start with '/'", defaultsAttr, fileInfo.Path, pathPrefix)
+		}
+
+		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+		for _, comment := range commentGroup.List[index+1:] {
+			err := operation.ParseComment(comment.Text, fileInfo.File)
+			if err != nil {
+				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
+			}
+		}
+
+		if len(operation.RouterProperties) > 0 {
+			return fmt.Errorf("%s in file %s: %s is not allowed in a defaults block", defaultsAttr, fileInfo.Path, routerAttr)
+		}
+
+		parser.operationDefaults = append(parser.operationDefaults, &operationDefaults{
+			pkgPath:    fileInfo.PackagePath,
+			pathPrefix: pathPrefix,
+			operation:  operation,
+		})
+	}
+
+	// path prefixes are more specific than packages, and longer prefixes more specific than shorter ones
+	sort.SliceStable(parser.operationDefaults, func(i, j int) bool {
+		return len(parser.operationDefaults[i].pathPrefix) > len(parser.operationDefaults[j].pathPrefix)
+	})
+
+	return nil
+}
+
+// applyOperationDefaults merges all the defaults matching operation into it, the most specific first.
+func (parser *Parser) applyOperationDefaults(operation *Operation, pkgPath string) {
+	for _, defaults := range parser.operationDefaults {
+		if defaults.matches(operation, pkgPath) {
+			operation.MergeDefaults(defaults.operation)
+		}
+	}
+}
+
+// MergeDefaults fills every attribute of operation which was not declared by its
+// own annotations with the one of defaults. Parameters are matched by location and
+// name, responses by status code.
+func (operation *Operation) MergeDefaults(defaults *Operation) {
+	if len(operation.Tags) == 0 {
+		operation.Tags = append(operation.Tags, defaults.Tags...)
+	}
+
+	if len(operation.Consumes) == 0 {
+		operation.Consumes = append(operation.Consumes, defaults.Consumes...)
+	}
+
+	if len(operation.Produces) == 0 {
+		operation.Produces = append(operation.Produces, defaults.Produces...)
+	}
+
+	if len(operation.Schemes) == 0 {
+		operation.Schemes = append(operation.Schemes, defaults.Schemes...)
+	}
+
+	// an empty @Security annotation explicitly disables the default security
+	if operation.Security == nil && defaults.Security != nil {
+		operation.Security = append([]map[string][]string{}, defaults.Security...)
+	}
+
+	if defaults.Deprecated {
+		operation.Deprecated = true
+	}
+
+	for _, param := range defaults.Parameters {
+		if !hasParameter(operation.Parameters, param) {
+			operation.Parameters = append(operation.Parameters, param)
+		}
+	}
+
+	if defaults.Responses != nil {
+		if operation.Responses.Default == nil && defaults.Responses.Default != nil {
+			response := copyResponse(*defaults.Responses.Default)
+			operation.Responses.Default = &response
+		}
+
+		for code, response := range defaults.Responses.StatusCodeResponses {
+			if _, ok := operation.Responses.StatusCodeResponses[code]; !ok {
+				operation.Responses.StatusCodeResponses[code] = copyResponse(response)
+			}
+		}
+	}
+
+	for key, value := range defaults.Extensions {
+		if _, ok := operation.Extensions[key]; !ok {
+			operation.Extensions[key] = value
+		}
+	}
+}
+
+func hasParameter(params []spec.Parameter, param spec.Parameter) bool {
+	for _, p := range params {
+		if p.In == param.In && p.Name == param.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyResponse copies response so that headers and extensions added to it later do not leak into other operations.
+func copyResponse(response spec.Response) spec.Response {
+	headers := make(map[string]spec.Header, len(response.Headers))
+	for key, header := range response.Headers {
+		headers[key] = header
+	}
+
+	response.Headers = headers
+
+	if response.Extensions != nil {
+		extensions := make(spec.Extensions, len(response.Extensions))
+		for key, value := range response.Extensions {
+			extensions[key] = value
+		}
+
+		response.Extensions = extensions
+	}
+
+	return response
+}
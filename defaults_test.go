@@ -0,0 +1,190 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_OperationDefaults(t *testing.T) {
+	t.Parallel()
+
+	doc := `
+// Package account handles accounts.
+//
+// @swag.defaults
+// @Tags account
+// @Produce json
+// @Security ApiKeyAuth
+// @Param X-Request-ID header string false "request id"
+// @Failure 401 {object} Error "unauthorized"
+package account
+`
+	src := `
+package account
+
+type Error struct {
+	Message string
+}
+
+// @Router /accounts [get]
+func List(){
+}
+
+// @Tags admin
+// @Produce xml
+// @Security
+// @Failure 401 {string} string "denied"
+// @Router /accounts/{id} [delete]
+func Delete(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api/account", "api/account/doc.go", doc, ParseAll))
+	assert.NoError(t, p.packages.ParseFile("api/account", "api/account/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.parseOperationDefaults))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	list := p.swagger.Paths.Paths["/accounts"].Get
+	assert.NotNil(t, list)
+	assert.Equal(t, []string{"account"}, list.Tags)
+	assert.Equal(t, []string{"application/json"}, list.Produces)
+	assert.Equal(t, []map[string][]string{{"ApiKeyAuth": {}}}, list.Security)
+	assert.Len(t, list.Parameters, 1)
+	assert.Equal(t, "X-Request-ID", list.Parameters[0].Name)
+	assert.Equal(t, "unauthorized", list.Responses.StatusCodeResponses[401].Description)
+	assert.Equal(t, spec.MustCreateRef("#/definitions/account.Error"), list.Responses.StatusCodeResponses[401].Schema.Ref)
+
+	del := p.swagger.Paths.Paths["/accounts/{id}"].Delete
+	assert.NotNil(t, del)
+	assert.Equal(t, []string{"admin"}, del.Tags)
+	assert.Equal(t, []string{"text/xml"}, del.Produces)
+	assert.Equal(t, []map[string][]string{}, del.Security)
+	assert.Equal(t, "denied", del.Responses.StatusCodeResponses[401].Description)
+}
+
+func TestParser_OperationDefaultsPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @swag.defaults /upload
+// @Tags upload
+// @Accept mpfd
+
+// @swag.defaults
+// @Tags api
+// @Accept json
+
+// @Router /upload/avatar [post]
+func Avatar(){
+}
+
+// @Router /uploads [get]
+func Uploads(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.parseOperationDefaults))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	avatar := p.swagger.Paths.Paths["/upload/avatar"].Post
+	assert.Equal(t, []string{"upload"}, avatar.Tags)
+	assert.Equal(t, []string{"multipart/form-data"}, avatar.Consumes)
+
+	uploads := p.swagger.Paths.Paths["/uploads"].Get
+	assert.Equal(t, []string{"api"}, uploads.Tags)
+	assert.Equal(t, []string{"application/json"}, uploads.Consumes)
+}
+
+func TestParser_OperationDefaultsTags(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @swag.defaults
+// @Tags admin
+
+// @Router /users [get]
+func Users(){
+}
+
+// @Tags public
+// @Router /health [get]
+func Health(){
+}
+`
+	parse := func(tags string) *spec.Paths {
+		p := New(SetTags(tags))
+		assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+		assert.NoError(t, p.packages.RangeFiles(p.parseOperationDefaults))
+		assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+		return p.swagger.Paths
+	}
+
+	paths := parse("!admin")
+	assert.NotContains(t, paths.Paths, "/users")
+	assert.Contains(t, paths.Paths, "/health")
+
+	paths = parse("admin")
+	assert.Contains(t, paths.Paths, "/users")
+	assert.NotContains(t, paths.Paths, "/health")
+}
+
+func TestOperation_MergeDefaultsResponseExtensions(t *testing.T) {
+	t.Parallel()
+
+	defaults := NewOperation(nil)
+	defaults.Responses.StatusCodeResponses[500] = spec.Response{
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-retry": true}},
+	}
+
+	first, second := NewOperation(nil), NewOperation(nil)
+	first.MergeDefaults(defaults)
+	second.MergeDefaults(defaults)
+
+	assert.Equal(t, true, first.Responses.StatusCodeResponses[500].Extensions["x-retry"])
+
+	first.Responses.StatusCodeResponses[500].Extensions["x-retry"] = false
+	assert.Equal(t, true, second.Responses.StatusCodeResponses[500].Extensions["x-retry"])
+	assert.Equal(t, true, defaults.Responses.StatusCodeResponses[500].Extensions["x-retry"])
+}
+
+func TestParser_OperationDefaultsErr(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @swag.defaults
+// @Router /api [get]
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.Error(t, p.packages.RangeFiles(p.parseOperationDefaults))
+
+	src = `
+package api
+
+// @swag.defaults api
+// @Tags api
+`
+	p = New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.Error(t, p.packages.RangeFiles(p.parseOperationDefaults))
+}
+
+func TestIsGeneralAPICommentWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, isGeneralAPIComment([]string{"@swag.defaults", "@Tags account"}))
+}
@@ -0,0 +1,64 @@
+package swag
+
+import (
+	"fmt"
+
+	"github.com/swaggo/swag/internal/load"
+	"golang.org/x/tools/go/packages"
+)
+
+// dependencyFiles returns the Go files of the dependencies of the package in dir, the ones imported
+// at most parseDepth imports away or all of them if it is 0, closest first.
+func (parser *Parser) dependencyFiles(dir string, parseDepth int) ([]goFile, error) {
+	roots, err := parser.loader.Deps(dir, ".")
+	if err != nil {
+		return nil, fmt.Errorf("pkg %s cannot find all dependencies, %s", dir, err)
+	}
+
+	visited := make(map[string]bool)
+	for _, root := range roots {
+		visited[root.PkgPath] = true
+	}
+
+	var files []goFile
+
+	level := roots
+	for depth := 1; (parseDepth <= 0 || depth <= parseDepth) && len(level) > 0; depth++ {
+		var next []*packages.Package
+
+		for _, pkg := range level {
+			for _, imported := range load.Imports(pkg) {
+				if visited[imported.PkgPath] {
+					continue
+				}
+
+				visited[imported.PkgPath] = true
+
+				files = append(files, parser.packageFiles(imported, parser.ParseDependency)...)
+				next = append(next, imported)
+			}
+		}
+
+		level = next
+	}
+
+	return files, nil
+}
+
+// packageFiles returns the Go files of pkg, including the ones importing "C".
+func (parser *Parser) packageFiles(pkg *packages.Package, parseFlag ParseFlag) []goFile {
+	if load.IsStandard(pkg) && !parser.ParseInternal {
+		return nil // ignored internal
+	}
+
+	if parser.skipPackageByPrefix(pkg.PkgPath) {
+		return nil // ignored by user-defined package path prefixes
+	}
+
+	files := make([]goFile, 0, len(pkg.GoFiles))
+	for _, path := range pkg.GoFiles {
+		files = append(files, goFile{packageDir: pkg.PkgPath, path: path, flag: parseFlag})
+	}
+
+	return files
+}
@@ -0,0 +1,71 @@
+package swag
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag/internal/load"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestDependencyFiles(t *testing.T) {
+	p := New(SetParseDependency(1))
+
+	dir, err := filepath.Abs("testdata/golist")
+	require.NoError(t, err)
+
+	p.loader = load.New(dir, nil, nil)
+
+	files, err := p.dependencyFiles(dir, 1)
+	require.NoError(t, err)
+
+	packageDirs := make(map[string]bool)
+	for _, file := range files {
+		packageDirs[file.packageDir] = true
+		assert.Equal(t, p.ParseDependency, file.flag)
+	}
+
+	// the cgo files are parsed, the standard library isn't
+	assert.True(t, packageDirs["github.com/swaggo/swag/testdata/golist/api"])
+	assert.True(t, packageDirs["github.com/swaggo/swag/example/basic/api"])
+	assert.False(t, packageDirs["net/http"])
+	assert.False(t, packageDirs["github.com/swaggo/swag/testdata/golist"])
+
+	// the dependencies of the dependencies are beyond the depth
+	assert.False(t, packageDirs["github.com/swaggo/swag/example/basic/web"])
+
+	files, err = p.dependencyFiles(dir, 0)
+	require.NoError(t, err)
+
+	packageDirs = make(map[string]bool)
+	for _, file := range files {
+		packageDirs[file.packageDir] = true
+	}
+
+	assert.True(t, packageDirs["github.com/swaggo/swag/example/basic/web"])
+}
+
+func TestPackageFiles(t *testing.T) {
+	p := New()
+
+	pkg := &packages.Package{
+		PkgPath: "github.com/swaggo/swag/testdata/golist/api",
+		GoFiles: []string{"/src/api/api.go"},
+		Module:  &packages.Module{Path: "github.com/swaggo/swag"},
+	}
+
+	assert.Equal(t, []goFile{{packageDir: pkg.PkgPath, path: "/src/api/api.go", flag: ParseModels}},
+		p.packageFiles(pkg, ParseModels))
+
+	standard := &packages.Package{PkgPath: "net/http", GoFiles: []string{"/goroot/src/net/http/server.go"}}
+
+	assert.Empty(t, p.packageFiles(standard, ParseModels))
+
+	p.ParseInternal = true
+	assert.Len(t, p.packageFiles(standard, ParseModels), 1)
+
+	p.packagePrefix = []string{"github.com/other"}
+	assert.Empty(t, p.packageFiles(pkg, ParseModels))
+}
@@ -0,0 +1,577 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Kinds of the changes found by DiffSwagger.
+const (
+	ChangeBasePath            = "base-path-changed"
+	ChangeOperationAdded      = "operation-added"
+	ChangeOperationRemoved    = "operation-removed"
+	ChangeOperationDeprecated = "operation-deprecated"
+	ChangeParameterAdded      = "parameter-added"
+	ChangeParameterRemoved    = "parameter-removed"
+	ChangeParameterRequired   = "parameter-required"
+	ChangeResponseAdded       = "response-added"
+	ChangeResponseRemoved     = "response-removed"
+	ChangeTypeChanged         = "type-changed"
+	ChangeEnumNarrowed        = "enum-narrowed"
+	ChangeEnumWidened         = "enum-widened"
+	ChangePropertyAdded       = "property-added"
+	ChangePropertyRemoved     = "property-removed"
+	ChangePropertyRequired    = "property-required"
+	ChangePropertyOptional    = "property-optional"
+)
+
+// Change describes a difference between two swagger documents.
+type Change struct {
+	// Kind the kind of the change, eg: operation-removed
+	Kind string `json:"kind"`
+
+	// Breaking whether clients of the base document may break with the revision
+	Breaking bool `json:"breaking"`
+
+	// Deprecated whether the operation is deprecated in the base document, so its breaking changes are allowed
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Method the HTTP method of the operation changed, if any
+	Method string `json:"method,omitempty"`
+
+	// Path the path of the operation changed, if any
+	Path string `json:"path,omitempty"`
+
+	// Location what is changed in the operation, eg: query limit, body.items[].name or response 200.id
+	Location string `json:"location,omitempty"`
+
+	// Message describes the change
+	Message string `json:"message"`
+}
+
+// DiffReport describes the changes between two swagger documents found by DiffSwagger.
+type DiffReport struct {
+	// Changes the changes, by operation in the order of their paths and methods
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes of the report.
+func (report *DiffReport) Breaking() []Change {
+	var changes []Change
+
+	for _, change := range report.Changes {
+		if change.Breaking {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+const (
+	requestDirection  = "request"
+	responseDirection = "response"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+type swaggerDiff struct {
+	base, revision *spec.Swagger
+	report         *DiffReport
+
+	// the operation being compared
+	method, path string
+	deprecated   bool
+
+	// the pairs of definitions being compared, to stop at recursive definitions
+	comparing map[string]bool
+}
+
+// DiffSwagger classifies the changes from the swagger document base to revision as breaking or not.
+// Removed operations and responses, new required parameters and properties, changed types, narrowed enums of
+// requests, widened enums of responses and removed response properties are breaking, unless the operation
+// is deprecated in base.
+func DiffSwagger(base, revision *spec.Swagger) *DiffReport {
+	diff := &swaggerDiff{
+		base:      base,
+		revision:  revision,
+		report:    &DiffReport{},
+		comparing: make(map[string]bool),
+	}
+
+	if base.BasePath != revision.BasePath {
+		diff.add(ChangeBasePath, true, "", fmt.Sprintf("base path changed from '%s' to '%s'", base.BasePath, revision.BasePath))
+	}
+
+	baseOperations, revisionOperations := diffOperations(base), diffOperations(revision)
+
+	keys := make([]string, 0, len(baseOperations)+len(revisionOperations))
+	for key := range baseOperations {
+		keys = append(keys, key)
+	}
+
+	for key := range revisionOperations {
+		if _, ok := baseOperations[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		baseOperation, inBase := baseOperations[key]
+		revisionOperation, inRevision := revisionOperations[key]
+
+		switch {
+		case !inRevision:
+			diff.setOperation(baseOperation)
+			diff.add(ChangeOperationRemoved, true, "", "operation removed")
+		case !inBase:
+			diff.setOperation(revisionOperation)
+			diff.add(ChangeOperationAdded, false, "", "operation added")
+		default:
+			diff.setOperation(revisionOperation)
+			diff.deprecated = baseOperation.Deprecated
+			diff.diffOperation(baseOperation, revisionOperation)
+		}
+	}
+
+	return diff.report
+}
+
+type diffOperation struct {
+	*spec.Operation
+	method, path string
+}
+
+// diffOperations returns the operations of swagger by method and path, with the names of path parameters left out
+// so an operation matches its revision whatever the names of its path parameters.
+func diffOperations(swagger *spec.Swagger) map[string]diffOperation {
+	operations := make(map[string]diffOperation)
+
+	if swagger.Paths == nil {
+		return operations
+	}
+
+	for path, item := range swagger.Paths.Paths {
+		for _, method := range sortedMethods() {
+			op := refRouteMethodOp(&item, method)
+			if *op == nil {
+				continue
+			}
+
+			key := pathParamPattern.ReplaceAllString(path, "{}") + " " + method
+			operations[key] = diffOperation{Operation: *op, method: method, path: path}
+		}
+	}
+
+	return operations
+}
+
+func (diff *swaggerDiff) setOperation(operation diffOperation) {
+	diff.method, diff.path = operation.method, operation.path
+	diff.deprecated = operation.Deprecated
+}
+
+func (diff *swaggerDiff) add(kind string, breaking bool, location, message string) {
+	change := Change{
+		Kind:     kind,
+		Breaking: breaking,
+		Method:   diff.method,
+		Path:     diff.path,
+		Location: location,
+		Message:  message,
+	}
+
+	if breaking && diff.deprecated {
+		change.Breaking = false
+		change.Deprecated = true
+	}
+
+	diff.report.Changes = append(diff.report.Changes, change)
+}
+
+func (diff *swaggerDiff) diffOperation(base, revision diffOperation) {
+	if !base.Deprecated && revision.Deprecated {
+		diff.add(ChangeOperationDeprecated, false, "", "operation deprecated")
+	}
+
+	baseParams, revisionParams := operationParameters(diff.base, base), operationParameters(diff.revision, revision)
+
+	keys := make([]string, 0, len(baseParams)+len(revisionParams))
+	for key := range baseParams {
+		keys = append(keys, key)
+	}
+
+	for key := range revisionParams {
+		if _, ok := baseParams[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		baseParam, inBase := baseParams[key]
+		revisionParam, inRevision := revisionParams[key]
+
+		switch {
+		case !inRevision:
+			diff.add(ChangeParameterRemoved, false, parameterLocation(baseParam), "parameter removed")
+		case !inBase && revisionParam.Required:
+			diff.add(ChangeParameterRequired, true, parameterLocation(revisionParam), "required parameter added")
+		case !inBase:
+			diff.add(ChangeParameterAdded, false, parameterLocation(revisionParam), "optional parameter added")
+		default:
+			location := parameterLocation(revisionParam)
+
+			if !baseParam.Required && revisionParam.Required {
+				diff.add(ChangeParameterRequired, true, location, "parameter became required")
+			}
+
+			if revisionParam.In == "body" {
+				diff.diffSchema(requestDirection, location, baseParam.Schema, revisionParam.Schema)
+			} else {
+				diff.diffSchema(requestDirection, location, parameterSchema(baseParam), parameterSchema(revisionParam))
+			}
+		}
+	}
+
+	baseResponses, revisionResponses := operationResponses(diff.base, base.Operation), operationResponses(diff.revision, revision.Operation)
+
+	codes := make([]string, 0, len(baseResponses)+len(revisionResponses))
+	for code := range baseResponses {
+		codes = append(codes, code)
+	}
+
+	for code := range revisionResponses {
+		if _, ok := baseResponses[code]; !ok {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		baseResponse, inBase := baseResponses[code]
+		revisionResponse, inRevision := revisionResponses[code]
+		location := responseDirection + " " + code
+
+		switch {
+		case !inRevision:
+			// clients may only rely on the successful responses
+			diff.add(ChangeResponseRemoved, strings.HasPrefix(code, "2"), location, "response removed")
+		case !inBase:
+			diff.add(ChangeResponseAdded, false, location, "response added")
+		default:
+			diff.diffSchema(responseDirection, location, baseResponse.Schema, revisionResponse.Schema)
+		}
+	}
+}
+
+// operationParameters returns the parameters of operation by location and name, path parameters by their position
+// in the path.
+func operationParameters(swagger *spec.Swagger, operation diffOperation) map[string]spec.Parameter {
+	placeholders := pathParamPattern.FindAllString(operation.path, -1)
+
+	params := make(map[string]spec.Parameter, len(operation.Parameters))
+
+	for _, param := range operation.Parameters {
+		if ref := param.Ref.String(); strings.HasPrefix(ref, "#/parameters/") {
+			resolved, ok := swagger.Parameters[strings.TrimPrefix(ref, "#/parameters/")]
+			if !ok {
+				continue
+			}
+
+			param = resolved
+		}
+
+		key := parameterLocation(param)
+
+		if param.In == "path" {
+			for i, placeholder := range placeholders {
+				if placeholder == "{"+param.Name+"}" {
+					key = param.In + " #" + strconv.Itoa(i)
+				}
+			}
+		}
+
+		params[key] = param
+	}
+
+	return params
+}
+
+// operationResponses returns the responses of operation by status code or default.
+func operationResponses(swagger *spec.Swagger, operation *spec.Operation) map[string]spec.Response {
+	responses := make(map[string]spec.Response)
+
+	if operation.Responses == nil {
+		return responses
+	}
+
+	resolve := func(response spec.Response) spec.Response {
+		if ref := response.Ref.String(); strings.HasPrefix(ref, "#/responses/") {
+			if resolved, ok := swagger.Responses[strings.TrimPrefix(ref, "#/responses/")]; ok {
+				return resolved
+			}
+		}
+
+		return response
+	}
+
+	for code, response := range operation.Responses.StatusCodeResponses {
+		responses[strconv.Itoa(code)] = resolve(response)
+	}
+
+	if operation.Responses.Default != nil {
+		responses["default"] = resolve(*operation.Responses.Default)
+	}
+
+	return responses
+}
+
+func parameterLocation(param spec.Parameter) string {
+	if param.In == "body" {
+		return "body"
+	}
+
+	return param.In + " " + param.Name
+}
+
+// parameterSchema returns the schema of the value of a non body parameter.
+func parameterSchema(param spec.Parameter) *spec.Schema {
+	schema := &spec.Schema{}
+	schema.Type = spec.StringOrArray{param.Type}
+	schema.Format = param.Format
+	schema.Enum = param.Enum
+
+	if param.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: itemsSchema(param.Items)}
+	}
+
+	return schema
+}
+
+func itemsSchema(items *spec.Items) *spec.Schema {
+	schema := &spec.Schema{}
+	schema.Type = spec.StringOrArray{items.Type}
+	schema.Format = items.Format
+	schema.Enum = items.Enum
+
+	if items.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: itemsSchema(items.Items)}
+	}
+
+	return schema
+}
+
+// diffSchema compares the schemas of a request or a response at location.
+func (diff *swaggerDiff) diffSchema(direction, location string, base, revision *spec.Schema) {
+	if base == nil || revision == nil {
+		if base != nil && direction == responseDirection {
+			diff.add(ChangeTypeChanged, true, location, "response body removed")
+		}
+
+		return
+	}
+
+	baseRef, revisionRef := base.Ref.String(), revision.Ref.String()
+
+	if baseRef != "" && revisionRef != "" {
+		key := direction + " " + baseRef + " " + revisionRef
+		if diff.comparing[key] {
+			return
+		}
+
+		diff.comparing[key] = true
+		defer delete(diff.comparing, key)
+	}
+
+	base, revision = flattenSchema(diff.base, base), flattenSchema(diff.revision, revision)
+
+	baseType, revisionType := schemaTypeName(base), schemaTypeName(revision)
+	if baseType != "" && revisionType != "" && baseType != revisionType {
+		diff.add(ChangeTypeChanged, true, location, fmt.Sprintf("type changed from %s to %s", baseType, revisionType))
+
+		return
+	}
+
+	diff.diffEnum(direction, location, base.Enum, revision.Enum)
+
+	names := make([]string, 0, len(base.Properties)+len(revision.Properties))
+	for name := range base.Properties {
+		names = append(names, name)
+	}
+
+	for name := range revision.Properties {
+		if _, ok := base.Properties[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		baseProperty, inBase := base.Properties[name]
+		revisionProperty, inRevision := revision.Properties[name]
+		propertyLocation := location + "." + name
+		baseRequired, revisionRequired := containsString(base.Required, name), containsString(revision.Required, name)
+
+		switch {
+		case !inRevision:
+			// servers ignore the properties they don't know of, clients may miss them
+			diff.add(ChangePropertyRemoved, direction == responseDirection, propertyLocation, "property removed")
+		case !inBase && revisionRequired && direction == requestDirection:
+			diff.add(ChangePropertyRequired, true, propertyLocation, "required property added")
+		case !inBase:
+			diff.add(ChangePropertyAdded, false, propertyLocation, "property added")
+		default:
+			if !baseRequired && revisionRequired && direction == requestDirection {
+				diff.add(ChangePropertyRequired, true, propertyLocation, "property became required")
+			}
+
+			if baseRequired && !revisionRequired && direction == responseDirection {
+				diff.add(ChangePropertyOptional, true, propertyLocation, "property became optional")
+			}
+
+			diff.diffSchema(direction, propertyLocation, &baseProperty, &revisionProperty)
+		}
+	}
+
+	if base.Items != nil && revision.Items != nil {
+		diff.diffSchema(direction, location+"[]", base.Items.Schema, revision.Items.Schema)
+	}
+
+	if base.AdditionalProperties != nil && revision.AdditionalProperties != nil {
+		diff.diffSchema(direction, location+"{}", base.AdditionalProperties.Schema, revision.AdditionalProperties.Schema)
+	}
+}
+
+// diffEnum compares the enums of a value: a request accepting fewer values or a response returning more values
+// is breaking.
+func (diff *swaggerDiff) diffEnum(direction, location string, base, revision []interface{}) {
+	baseValues, revisionValues := enumValues(base), enumValues(revision)
+
+	var removed, added []string
+
+	for _, value := range baseValues {
+		if len(revision) > 0 && !containsString(revisionValues, value) {
+			removed = append(removed, value)
+		}
+	}
+
+	for _, value := range revisionValues {
+		if len(base) > 0 && !containsString(baseValues, value) {
+			added = append(added, value)
+		}
+	}
+
+	switch {
+	case len(base) == 0 && len(revision) > 0:
+		diff.add(ChangeEnumNarrowed, direction == requestDirection, location, "values restricted to "+strings.Join(revisionValues, ", "))
+	case len(base) > 0 && len(revision) == 0:
+		diff.add(ChangeEnumWidened, direction == responseDirection, location, "values no longer restricted")
+	}
+
+	if len(removed) > 0 {
+		diff.add(ChangeEnumNarrowed, direction == requestDirection, location, "values removed: "+strings.Join(removed, ", "))
+	}
+
+	if len(added) > 0 {
+		diff.add(ChangeEnumWidened, direction == responseDirection, location, "values added: "+strings.Join(added, ", "))
+	}
+}
+
+func enumValues(enum []interface{}) []string {
+	values := make([]string, 0, len(enum))
+
+	for _, value := range enum {
+		b, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+
+		values = append(values, string(b))
+	}
+
+	return values
+}
+
+// flattenSchema resolves the reference of schema to a definition of swagger and merges its allOf schemas.
+func flattenSchema(swagger *spec.Swagger, schema *spec.Schema) *spec.Schema {
+	for depth := 0; schema.Ref.String() != "" && depth < 32; depth++ {
+		definition, ok := swagger.Definitions[strings.TrimPrefix(schema.Ref.String(), definitionsRefPrefix)]
+		if !ok {
+			return schema
+		}
+
+		schema = &definition
+	}
+
+	if len(schema.AllOf) == 0 {
+		return schema
+	}
+
+	flattened := *schema
+	flattened.AllOf = nil
+	flattened.Properties = make(spec.SchemaProperties)
+	flattened.Required = append([]string{}, schema.Required...)
+
+	for name, property := range schema.Properties {
+		flattened.Properties[name] = property
+	}
+
+	for i := range schema.AllOf {
+		member := flattenSchema(swagger, &schema.AllOf[i])
+
+		if len(flattened.Type) == 0 {
+			flattened.Type = member.Type
+		}
+
+		for name, property := range member.Properties {
+			if _, ok := flattened.Properties[name]; !ok {
+				flattened.Properties[name] = property
+			}
+		}
+
+		flattened.Required = append(flattened.Required, member.Required...)
+
+		if flattened.Items == nil {
+			flattened.Items = member.Items
+		}
+
+		if flattened.Enum == nil {
+			flattened.Enum = member.Enum
+		}
+	}
+
+	return &flattened
+}
+
+func schemaTypeName(schema *spec.Schema) string {
+	switch {
+	case len(schema.Type) > 0:
+		return schema.Type[0]
+	case len(schema.Properties) > 0:
+		return OBJECT
+	default:
+		return ""
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
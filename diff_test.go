@@ -0,0 +1,172 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSwagger(t *testing.T) {
+	t.Parallel()
+
+	base := `{
+		"swagger": "2.0",
+		"basePath": "/v1",
+		"paths": {
+			"/pets/{id}": {
+				"get": {
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "type": "integer"},
+						{"name": "status", "in": "query", "type": "string", "enum": ["available", "sold"]},
+						{"name": "limit", "in": "query", "type": "integer"}
+					],
+					"responses": {
+						"200": {"schema": {"$ref": "#/definitions/model.Pet"}},
+						"404": {"description": "not found"}
+					}
+				},
+				"delete": {
+					"deprecated": true,
+					"responses": {"204": {"description": "deleted"}}
+				}
+			},
+			"/pets": {
+				"post": {
+					"parameters": [{"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.Pet"}}],
+					"responses": {"201": {"description": "created"}}
+				},
+				"put": {
+					"responses": {"200": {"description": "updated"}}
+				}
+			}
+		},
+		"definitions": {
+			"model.Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer"},
+					"tags": {"type": "array", "items": {"$ref": "#/definitions/model.Tag"}},
+					"parent": {"$ref": "#/definitions/model.Pet"}
+				}
+			},
+			"model.Tag": {
+				"type": "object",
+				"properties": {"label": {"type": "string"}}
+			}
+		}
+	}`
+
+	revision := `{
+		"swagger": "2.0",
+		"basePath": "/v1",
+		"paths": {
+			"/pets/{petId}": {
+				"get": {
+					"parameters": [
+						{"name": "petId", "in": "path", "required": true, "type": "integer"},
+						{"name": "status", "in": "query", "type": "string", "enum": ["available"]},
+						{"name": "limit", "in": "query", "type": "integer"},
+						{"name": "owner", "in": "query", "required": true, "type": "string"}
+					],
+					"responses": {
+						"200": {"schema": {"$ref": "#/definitions/model.Pet"}}
+					}
+				}
+			},
+			"/pets": {
+				"post": {
+					"parameters": [{"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.Pet"}}],
+					"responses": {"201": {"description": "created"}}
+				}
+			}
+		},
+		"definitions": {
+			"model.Pet": {
+				"type": "object",
+				"required": ["name", "owner"],
+				"properties": {
+					"name": {"type": "string"},
+					"age": {"type": "string"},
+					"owner": {"type": "string"},
+					"tags": {"type": "array", "items": {"$ref": "#/definitions/model.Tag"}},
+					"parent": {"$ref": "#/definitions/model.Pet"}
+				}
+			},
+			"model.Tag": {
+				"type": "object",
+				"properties": {}
+			}
+		}
+	}`
+
+	var baseSwagger, revisionSwagger spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(base), &baseSwagger))
+	require.NoError(t, json.Unmarshal([]byte(revision), &revisionSwagger))
+
+	report := DiffSwagger(&baseSwagger, &revisionSwagger)
+
+	type change struct {
+		kind, operation, location string
+		breaking, deprecated      bool
+	}
+
+	var changes []change
+	for _, c := range report.Changes {
+		changes = append(changes, change{c.Kind, c.Method + " " + c.Path, c.Location, c.Breaking, c.Deprecated})
+	}
+
+	assert.Equal(t, []change{
+		{ChangeTypeChanged, "POST /pets", "body.age", true, false},
+		{ChangePropertyRequired, "POST /pets", "body.owner", true, false},
+		{ChangePropertyRemoved, "POST /pets", "body.tags[].label", false, false},
+		{ChangeOperationRemoved, "PUT /pets", "", true, false},
+		// the operation was deprecated first
+		{ChangeOperationRemoved, "DELETE /pets/{id}", "", false, true},
+		{ChangeParameterRequired, "GET /pets/{petId}", "query owner", true, false},
+		{ChangeEnumNarrowed, "GET /pets/{petId}", "query status", true, false},
+		{ChangeTypeChanged, "GET /pets/{petId}", "response 200.age", true, false},
+		{ChangePropertyAdded, "GET /pets/{petId}", "response 200.owner", false, false},
+		{ChangePropertyRemoved, "GET /pets/{petId}", "response 200.tags[].label", true, false},
+		{ChangeResponseRemoved, "GET /pets/{petId}", "response 404", false, false},
+	}, changes)
+
+	assert.Len(t, report.Breaking(), 7)
+}
+
+func TestDiffSwagger_Enums(t *testing.T) {
+	t.Parallel()
+
+	schema := func(enum ...interface{}) spec.Swagger {
+		return spec.Swagger{SwaggerProps: spec.SwaggerProps{
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/status": {PathItemProps: spec.PathItemProps{Get: &spec.Operation{OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{
+						200: {ResponseProps: spec.ResponseProps{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{
+							Type: []string{STRING},
+							Enum: enum,
+						}}}},
+					}}},
+				}}}},
+			}},
+		}}
+	}
+
+	base, revision := schema("on", "off"), schema("on", "off", "unknown")
+
+	// clients of a response may not handle the new value
+	report := DiffSwagger(&base, &revision)
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, ChangeEnumWidened, report.Changes[0].Kind)
+	assert.True(t, report.Changes[0].Breaking)
+	assert.Equal(t, `values added: "unknown"`, report.Changes[0].Message)
+
+	report = DiffSwagger(&revision, &base)
+	require.Len(t, report.Changes, 1)
+	assert.Equal(t, ChangeEnumNarrowed, report.Changes[0].Kind)
+	assert.False(t, report.Changes[0].Breaking)
+}
@@ -261,16 +261,50 @@ func (ps *tagBaseFieldParser) ComplementSchema(schema *spec.Schema) error {
 	return ps.complementSchema(schema, types)
 }
 
+// fieldDescription returns the description of the field from its comments, and the extensions set by
+// the @Since and @Changed lines of its comments.
+func (ps *tagBaseFieldParser) fieldDescription() (string, spec.Extensions, error) {
+	var description string
+
+	extensions := make(spec.Extensions)
+
+	for _, comments := range []*ast.CommentGroup{ps.field.Doc, ps.field.Comment} {
+		if comments == nil {
+			continue
+		}
+
+		text, err := parseFieldVersions(comments.Text(), extensions)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if description == "" {
+			description = text
+		}
+	}
+
+	return description, extensions, nil
+}
+
+func addExtensions(schema *spec.Schema, extensions spec.Extensions) {
+	for key, value := range extensions {
+		schema.AddExtension(key, value)
+	}
+}
+
 // complementSchema complement schema with field properties
 func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []string) error {
+	description, versions, err := ps.fieldDescription()
+	if err != nil {
+		return err
+	}
+
 	if ps.field.Tag == nil {
-		if ps.field.Doc != nil {
-			schema.Description = strings.TrimSpace(ps.field.Doc.Text())
+		if description != "" {
+			schema.Description = description
 		}
 
-		if schema.Description == "" && ps.field.Comment != nil {
-			schema.Description = strings.TrimSpace(ps.field.Comment.Text())
-		}
+		addExtensions(schema, versions)
 
 		return nil
 	}
@@ -394,12 +428,8 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		}
 	}
 
-	if ps.field.Doc != nil {
-		schema.Description = strings.TrimSpace(ps.field.Doc.Text())
-	}
-
-	if schema.Description == "" && ps.field.Comment != nil {
-		schema.Description = strings.TrimSpace(ps.field.Comment.Text())
+	if description != "" {
+		schema.Description = description
 	}
 
 	schema.ReadOnly = ps.tag.Get(readOnlyTag) == "true"
@@ -426,6 +456,21 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		schema.Extensions = setExtensionParam(extensionsTagValue)
 	}
 
+	visibilityTagValue := ps.tag.Get(visibilityTag)
+	if visibilityTagValue != "" {
+		if _, ok := visibilityRanks[visibilityTagValue]; !ok {
+			return fmt.Errorf("invalid visibility: %s", visibilityTagValue)
+		}
+
+		if schema.Extensions == nil {
+			schema.Extensions = make(spec.Extensions)
+		}
+
+		schema.Extensions[visibilityExtension] = visibilityTagValue
+	}
+
+	addExtensions(schema, versions)
+
 	varNamesTag := ps.tag.Get("x-enum-varnames")
 	if varNamesTag != "" {
 		varNames := strings.Split(varNamesTag, ",")
@@ -0,0 +1,194 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+func (g *Gen) writeChangelog(config *Config, swagger *spec.Swagger) error {
+	changelogFileName := path.Join(config.OutputDir, outputFilename(config, "changelog.md"))
+
+	err := g.writeFile(buildChangelog(swagger), changelogFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create changelog.md at %+v", changelogFileName)
+
+	return nil
+}
+
+type changelogEntry struct {
+	subject     string
+	description string
+}
+
+// changelogVersion the operations and fields added and changed in a version.
+type changelogVersion struct {
+	added   []changelogEntry
+	changed []changelogEntry
+}
+
+type changelog map[string]*changelogVersion
+
+func (c changelog) version(version string) *changelogVersion {
+	if c[version] == nil {
+		c[version] = &changelogVersion{}
+	}
+
+	return c[version]
+}
+
+// add adds the entries of the x-since and x-changes extensions to the changelog.
+func (c changelog) add(subject, description string, extensions spec.Extensions) {
+	if since, ok := extensions.GetString("x-since"); ok {
+		version := c.version(since)
+		version.added = append(version.added, changelogEntry{subject: subject, description: description})
+	}
+
+	changes, _ := extensions["x-changes"].([]interface{})
+	for _, change := range changes {
+		change, ok := change.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		versionName, _ := change["version"].(string)
+		changeDescription, _ := change["description"].(string)
+
+		version := c.version(versionName)
+		version.changed = append(version.changed, changelogEntry{subject: subject, description: changeDescription})
+	}
+}
+
+// addProperties adds the changelog entries of the properties of schema, named after prefix.
+func (c changelog) addProperties(prefix string, schema *spec.Schema) {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := schema.Properties[name]
+		c.add(prefix+"."+name, property.Description, property.Extensions)
+		c.addProperties(prefix+"."+name, &property)
+
+		if property.Items != nil && property.Items.Schema != nil {
+			c.addProperties(prefix+"."+name+"[]", property.Items.Schema)
+		}
+	}
+
+	for i := range schema.AllOf {
+		c.addProperties(prefix, &schema.AllOf[i])
+	}
+}
+
+// buildChangelog writes the operations and fields annotated with @Since and @Changed as a markdown
+// changelog, grouped by version from the latest one.
+func buildChangelog(swagger *spec.Swagger) []byte {
+	entries := make(changelog)
+
+	for _, op := range listOperations(swagger) {
+		entries.add(op.Method+" "+op.Path, op.Operation.Summary, op.Operation.Extensions)
+	}
+
+	names := make([]string, 0, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition := swagger.Definitions[name]
+		entries.addProperties(name, &definition)
+	}
+
+	versions := make([]string, 0, len(entries))
+	for version := range entries {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) > 0
+	})
+
+	var buf bytes.Buffer
+
+	title := "Changelog"
+	if swagger.Info != nil && swagger.Info.Title != "" {
+		title = swagger.Info.Title + " changelog"
+	}
+
+	fmt.Fprintf(&buf, "# %s\n", title)
+
+	for _, version := range versions {
+		fmt.Fprintf(&buf, "\n## %s\n", version)
+
+		writeChangelogSection(&buf, "Added", entries[version].added)
+		writeChangelogSection(&buf, "Changed", entries[version].changed)
+	}
+
+	return buf.Bytes()
+}
+
+func writeChangelogSection(buf *bytes.Buffer, title string, entries []changelogEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "\n### %s\n\n", title)
+
+	for _, entry := range entries {
+		fmt.Fprintf(buf, "- `%s`", entry.subject)
+
+		if description := strings.Join(strings.Fields(entry.description), " "); description != "" {
+			fmt.Fprintf(buf, ": %s", description)
+		}
+
+		buf.WriteString("\n")
+	}
+}
+
+// compareVersions compares versions like v1.4 or 2.0.1-beta by their numeric parts, and as strings otherwise.
+func compareVersions(a, b string) int {
+	partsA := strings.FieldsFunc(strings.TrimPrefix(a, "v"), isVersionSeparator)
+	partsB := strings.FieldsFunc(strings.TrimPrefix(b, "v"), isVersionSeparator)
+
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+
+		switch {
+		case errA == nil && errB == nil && numberA != numberB:
+			if numberA < numberB {
+				return -1
+			}
+
+			return 1
+		case (errA != nil || errB != nil) && partsA[i] != partsB[i]:
+			return strings.Compare(partsA[i], partsB[i])
+		}
+	}
+
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func isVersionSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '+'
+}
@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, -1, compareVersions("v1.4", "v1.10"))
+	assert.Equal(t, 1, compareVersions("v2.0", "v1.10"))
+	assert.Equal(t, -1, compareVersions("v1.4", "v1.4.1"))
+	assert.Equal(t, 0, compareVersions("v1.4", "v1.4"))
+	assert.Equal(t, -1, compareVersions("v2.0-alpha", "v2.0-beta"))
+}
+
+func TestBuildChangelog(t *testing.T) {
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"info": {"title": "Pet store"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"summary": "List pets",
+					"x-since": "v1.0",
+					"x-changes": [{"version": "v1.10", "description": "paginated"}]
+				},
+				"post": {
+					"summary": "Create a pet",
+					"x-since": "v1.10"
+				}
+			}
+		},
+		"definitions": {
+			"model.Pet": {
+				"type": "object",
+				"properties": {
+					"name": {
+						"type": "string",
+						"description": "Name of the pet",
+						"x-changes": [{"version": "v1.4", "description": "up to 64 characters"}]
+					},
+					"owner": {
+						"description": "Owner of the pet",
+						"x-since": "v1.4",
+						"allOf": [{"$ref": "#/definitions/model.Owner"}]
+					},
+					"tags": {
+						"type": "array",
+						"items": {
+							"type": "object",
+							"properties": {"label": {"type": "string", "x-since": "v1.10"}}
+						}
+					}
+				}
+			}
+		}
+	}`), &swagger))
+
+	assert.Equal(t, "# Pet store changelog\n"+
+		"\n## v1.10\n"+
+		"\n### Added\n\n"+
+		"- `POST /pets`: Create a pet\n"+
+		"- `model.Pet.tags[].label`\n"+
+		"\n### Changed\n\n"+
+		"- `GET /pets`: paginated\n"+
+		"\n## v1.4\n"+
+		"\n### Added\n\n"+
+		"- `model.Pet.owner`: Owner of the pet\n"+
+		"\n### Changed\n\n"+
+		"- `model.Pet.name`: up to 64 characters\n"+
+		"\n## v1.0\n"+
+		"\n### Added\n\n"+
+		"- `GET /pets`: List pets\n", string(buildChangelog(&swagger)))
+}
@@ -0,0 +1,123 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+func (g *Gen) mkdirAll(dir string) error {
+	if g.generated != nil {
+		return nil
+	}
+
+	return os.MkdirAll(dir, os.ModePerm)
+}
+
+func (g *Gen) removeFile(file string) error {
+	if g.generated != nil {
+		g.generated[filepath.Clean(file)] = nil
+
+		return nil
+	}
+
+	return os.Remove(file)
+}
+
+// checkGenerated compares the files generated in memory with the ones on disk, writes the differences to
+// config.CheckOutput and fails if there are any.
+func (g *Gen) checkGenerated(config *Config) error {
+	output := config.CheckOutput
+	if output == nil {
+		output = os.Stdout
+	}
+
+	files := make([]string, 0, len(g.generated))
+	for file := range g.generated {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	var outdated int
+
+	for _, file := range files {
+		generated := g.generated[file]
+
+		existing, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		exists := err == nil
+
+		switch {
+		case generated == nil && !exists:
+			continue
+		case generated != nil && exists && bytes.Equal(existing, generated):
+			continue
+		}
+
+		outdated++
+
+		switch {
+		case generated == nil:
+			_, err = fmt.Fprintf(output, "%s should be removed\n", file)
+		case !exists:
+			_, err = fmt.Fprintf(output, "%s is missing\n", file)
+		default:
+			var diff string
+
+			diff, err = unifiedDiff(file, string(existing), string(generated))
+			if err == nil {
+				_, err = io.WriteString(output, diff)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if outdated > 0 {
+		return fmt.Errorf("%d generated files are out of date, run swag init to update them", outdated)
+	}
+
+	g.debug.Printf("%d generated files are up to date", len(files))
+
+	return nil
+}
+
+// unifiedDiff returns the differences of the lines of the file on disk a and the generated file b in the
+// unified format, with 3 lines of context.
+func unifiedDiff(file, a, b string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(a),
+		B:        splitLines(b),
+		FromFile: file,
+		ToFile:   file + " (generated)",
+		Context:  3,
+	})
+}
+
+// splitLines splits s into lines ending with a newline, unlike difflib.SplitLines which adds an empty line
+// after the final newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+
+	return lines[:len(lines)-1]
+}
@@ -0,0 +1,107 @@
+package gen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+
+	diff, err := unifiedDiff("docs/swagger.json", a, b)
+	require.NoError(t, err)
+	assert.Equal(t, `--- docs/swagger.json
++++ docs/swagger.json (generated)
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -13,3 +13,4 @@
+ 13
+ 14
+ 15
++16
+`, diff)
+
+	diff, err = unifiedDiff("docs.go", "a\nc\n", "b\nc\n")
+	require.NoError(t, err)
+	assert.Equal(t, `--- docs.go
++++ docs.go (generated)
+@@ -1,2 +1,2 @@
+-a
++b
+ c
+`, diff)
+}
+
+func TestGen_Check(t *testing.T) {
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/simple/docs",
+		OutputTypes: []string{"go", "json", "yaml"},
+	}
+
+	var output bytes.Buffer
+
+	config.Check = true
+	config.CheckOutput = &output
+
+	// nothing generated yet
+	assert.EqualError(t, New().Build(config), "3 generated files are out of date, run swag init to update them")
+	assert.NoDirExists(t, config.OutputDir)
+	assert.Contains(t, output.String(), filepath.Join(config.OutputDir, "swagger.json")+" is missing\n")
+
+	config.Check = false
+	require.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	output.Reset()
+
+	config.Check = true
+	require.NoError(t, New().Build(config))
+	assert.Empty(t, output.String())
+
+	jsonFile := filepath.Join(config.OutputDir, "swagger.json")
+
+	b, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+
+	changed := strings.Replace(string(b), `"basePath": "/v2",`, `"basePath": "/v1",`, 1)
+	require.NoError(t, os.WriteFile(jsonFile, []byte(changed), 0644))
+
+	assert.EqualError(t, New().Build(config), "1 generated files are out of date, run swag init to update them")
+	assert.Contains(t, output.String(), "--- "+jsonFile+"\n+++ "+jsonFile+" (generated)\n")
+	assert.Contains(t, output.String(), "\n-    \"basePath\": \"/v1\",\n+    \"basePath\": \"/v2\",\n")
+
+	// the files are left as they are
+	b, err = os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	assert.Equal(t, changed, string(b))
+}
+
+func TestGen_CheckGeneratedTime(t *testing.T) {
+	config := &Config{
+		SearchDir:     searchDir,
+		MainAPIFile:   "./main.go",
+		OutputDir:     "../testdata/simple/docs",
+		OutputTypes:   []string{"go"},
+		GeneratedTime: true,
+		Check:         true,
+	}
+
+	assert.Error(t, New().Build(config))
+}
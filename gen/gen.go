@@ -8,9 +8,12 @@ import (
 	"go/format"
 	"io"
 	"log"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -36,6 +39,19 @@ type Gen struct {
 	jsonToYAML    func(data []byte) ([]byte, error)
 	outputTypeMap map[string]genTypeWriter
 	debug         Debugger
+
+	// definitionGoTypes the Go types definitions are generated from, used by the goclient output
+	definitionGoTypes map[string]swag.GoType
+
+	// jsonSchemaRoots the definitions selected for the jsonschema output
+	jsonSchemaRoots []string
+
+	// jsonSchemaDefinitions the definitions the selected ones refer to which are not in the swagger document
+	jsonSchemaDefinitions spec.Definitions
+
+	// generated the content of the files by path when checking them instead of writing them,
+	// nil for the files to remove
+	generated map[string][]byte
 }
 
 // Debugger is the interface that wraps the basic Printf method.
@@ -55,10 +71,18 @@ func New() *Gen {
 	}
 
 	gen.outputTypeMap = map[string]genTypeWriter{
-		"go":   gen.writeDocSwagger,
-		"json": gen.writeJSONSwagger,
-		"yaml": gen.writeYAMLSwagger,
-		"yml":  gen.writeYAMLSwagger,
+		"go":         gen.writeDocSwagger,
+		"json":       gen.writeJSONSwagger,
+		"yaml":       gen.writeYAMLSwagger,
+		"yml":        gen.writeYAMLSwagger,
+		"postman":    gen.writePostmanCollection,
+		"http":       gen.writeHTTPRequests,
+		"markdown":   gen.writeMarkdownReference,
+		"html":       gen.writeHTMLReference,
+		"ts":         gen.writeTypeScript,
+		"jsonschema": gen.writeJSONSchemas,
+		"goclient":   gen.writeGoClient,
+		"changelog":  gen.writeChangelog,
 	}
 
 	return &gen
@@ -124,8 +148,26 @@ type Config struct {
 	OverridesFile string
 
 	// ParseGoList whether swag use go list to parse dependency
+	//
+	// Deprecated: the dependencies are always loaded with go/packages.
 	ParseGoList bool
 
+	// BuildTags the build tags the build constraints of the files are evaluated with, comma separated
+	BuildTags string
+
+	// GOOS the target operating system the build constraints of the files are evaluated for, the one of the go command if empty
+	GOOS string
+
+	// GOARCH the target architecture the build constraints of the files are evaluated for, the one of the go command if empty
+	GOARCH string
+
+	// ParseCacheDir the directory where the sources of the parsed files stripped of what swag doesn't read
+	// are cached across runs, disabled if empty
+	ParseCacheDir string
+
+	// Jobs the number of files parsed concurrently, as many as CPUs if 0
+	Jobs int
+
 	// include only tags mentioned when searching, comma separated
 	Tags string
 
@@ -149,10 +191,82 @@ type Config struct {
 
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
+
+	// Visibilities to write additional documents for, filtered by swag.FilterVisibility
+	// and named <InstanceName>_<visibility>
+	Visibilities []string
+
+	// PruneDefinitions whether swag should remove the definitions not reachable from any operation
+	PruneDefinitions bool
+
+	// KeepDefinitions name patterns of definitions to keep when pruning, eg: model.*
+	KeepDefinitions []string
+
+	// MarkdownTemplate the Go template file replacing the default layout of the markdown output,
+	// executed with a ReferenceDoc
+	MarkdownTemplate string
+
+	// HTMLTemplate the Go template file replacing the default layout of the html output,
+	// executed with a ReferenceDoc
+	HTMLTemplate string
+
+	// JSONSchemaTypes the types exported by the jsonschema output besides the ones annotated with @JSONSchema,
+	// every definition is exported if none is selected
+	JSONSchemaTypes []string
+
+	// JSONSchemaBundle whether the jsonschema output writes a bundle with $defs instead of a file per definition
+	JSONSchemaBundle bool
+
+	// JSONSchemaID the base URI of the $id of the JSON Schemas
+	JSONSchemaID string
+
+	// Check regenerates the files in memory and reports the ones which differ from the files on disk
+	// instead of writing them
+	Check bool
+
+	// CheckOutput where the differences found by Check are written, os.Stdout if nil
+	CheckOutput io.Writer
+
+	// Versions the API versions to write a swagger document for besides the one of every operation,
+	// with the operations of the version only according to their @Version annotation, eg: v1 or v1=/api/v1
+	// to set its base path. They are named <InstanceName>_<version>, with _ for the characters of the version
+	// which aren't valid in Go identifiers
+	Versions []string
+
+	// SplitDefinitions writes the definitions of swagger.json and swagger.yaml into separate files
+	// of the definitions directory, by package or by type, referred to by relative $refs
+	SplitDefinitions string
+
+	// derived the document the docs.go file derives its document from, set for the versions
+	derived *derivedDoc
+
+	// definitionsOf the instance whose split definitions the documents refer to instead of writing their own
+	definitionsOf string
+}
+
+// derivedDoc is a document the docs.go file of an instance derives from the document of another instance
+// when its package is initialized, instead of embedding it, so the definitions they share are written once.
+type derivedDoc struct {
+	// instanceName the instance of the document it derives from
+	instanceName string
+
+	// method the method of swag.Spec deriving the document, called with args, eg: VersionView("v1", "/api/v1")
+	method string
+	args   []string
 }
 
 // Build builds swagger json file  for given searchDir and mainAPIFile. Returns json.
 func (g *Gen) Build(config *Config) error {
+	p, err := g.parse(config)
+	if err != nil {
+		return err
+	}
+
+	return g.write(config, p)
+}
+
+// parse checks config and parses the document of its search dirs.
+func (g *Gen) parse(config *Config) (*swag.Parser, error) {
 	if config.Debugger != nil {
 		g.debug = config.Debugger
 	}
@@ -160,10 +274,32 @@ func (g *Gen) Build(config *Config) error {
 		config.InstanceName = swag.Name
 	}
 
+	if config.Check && config.GeneratedTime {
+		return nil, fmt.Errorf("check can't be used with generatedTime, the timestamp of docs.go differs on every run")
+	}
+
+	switch config.SplitDefinitions {
+	case "", SplitByPackage, SplitByType:
+	default:
+		return nil, fmt.Errorf("not supported %s splitDefinitions, use %s or %s", config.SplitDefinitions, SplitByPackage, SplitByType)
+	}
+
+	versions := make(map[string]bool, len(config.Versions))
+	for _, version := range config.Versions {
+		name, _ := versionBasePath("", version)
+
+		instanceName := versionInstanceName(config.InstanceName, name)
+		if name == "" || versions[instanceName] {
+			return nil, fmt.Errorf("invalid or duplicated version: %s", version)
+		}
+
+		versions[instanceName] = true
+	}
+
 	searchDirs := strings.Split(config.SearchDir, ",")
 	for _, searchDir := range searchDirs {
 		if _, err := os.Stat(searchDir); os.IsNotExist(err) {
-			return fmt.Errorf("dir: %s does not exist", searchDir)
+			return nil, fmt.Errorf("dir: %s does not exist", searchDir)
 		}
 	}
 	if config.LeftTemplateDelim == "" {
@@ -181,14 +317,14 @@ func (g *Gen) Build(config *Config) error {
 		if err != nil {
 			// Don't bother reporting if the default file is missing; assume there are no overrides
 			if !(config.OverridesFile == DefaultOverridesFile && os.IsNotExist(err)) {
-				return fmt.Errorf("could not open overrides file: %w", err)
+				return nil, fmt.Errorf("could not open overrides file: %w", err)
 			}
 		} else {
 			g.debug.Printf("Using overrides from %s", config.OverridesFile)
 
 			overrides, err = parseOverrides(overridesFile)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -204,10 +340,14 @@ func (g *Gen) Build(config *Config) error {
 		swag.SetCodeExamplesDirectory(config.CodeExampleFilesDir),
 		swag.SetStrict(config.Strict),
 		swag.SetOverrides(overrides),
-		swag.ParseUsingGoList(config.ParseGoList),
+		swag.SetBuildTags(splitBuildTags(config.BuildTags)),
+		swag.SetBuildTarget(config.GOOS, config.GOARCH),
+		swag.SetParseCacheDirectory(config.ParseCacheDir),
+		swag.SetJobs(config.Jobs),
 		swag.SetTags(config.Tags),
 		swag.SetCollectionFormat(config.CollectionFormat),
 		swag.SetPackagePrefix(config.PackagePrefix),
+		swag.SetJSONSchemaTypes(config.JSONSchemaTypes),
 	)
 
 	p.PropNamingStrategy = config.PropNamingStrategy
@@ -218,15 +358,167 @@ func (g *Gen) Build(config *Config) error {
 	p.ParseFuncBody = config.ParseFuncBody
 
 	if err := p.ParseAPIMultiSearchDir(searchDirs, config.MainAPIFile, config.ParseDepth); err != nil {
-		return err
+		return nil, err
 	}
 
+	return p, nil
+}
+
+// write writes the outputs of the document parsed by p.
+func (g *Gen) write(config *Config, p *swag.Parser) error {
 	swagger := p.GetSwagger()
+	g.definitionGoTypes = p.DefinitionGoTypes()
+	g.jsonSchemaRoots, g.jsonSchemaDefinitions = p.JSONSchemaDefinitions()
+
+	if config.PruneDefinitions {
+		// the document of p is kept whole, Reparse builds on it
+		pruned := *swagger
+		pruned.Definitions = maps.Clone(swagger.Definitions)
+		swagger = &pruned
 
-	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
+		if err := g.pruneDefinitions(config, swagger); err != nil {
+			return err
+		}
+	}
+
+	if config.Check {
+		g.generated = make(map[string][]byte)
+		defer func() { g.generated = nil }()
+	}
+
+	if err := g.mkdirAll(config.OutputDir); err != nil {
 		return err
 	}
 
+	if err := g.writeVariants(config, swagger); err != nil {
+		return err
+	}
+
+	for _, version := range config.Versions {
+		name, basePath := versionBasePath(swagger.BasePath, version)
+
+		filtered, err := swag.FilterVersion(swagger, name, basePath)
+		if err != nil {
+			return err
+		}
+
+		// the definitions of a version are the ones of the document it is filtered from
+		versionConfig := *config
+		versionConfig.InstanceName = versionInstanceName(config.InstanceName, name)
+		versionConfig.derived = &derivedDoc{
+			instanceName: config.InstanceName,
+			method:       "VersionView",
+			args:         []string{name, basePath},
+		}
+		versionConfig.definitionsOf = config.InstanceName
+
+		if err := g.writeVariants(&versionConfig, filtered); err != nil {
+			return err
+		}
+	}
+
+	if config.Check {
+		return g.checkGenerated(config)
+	}
+
+	return nil
+}
+
+// writeVariants writes the outputs of swagger, and of its visibility variants.
+func (g *Gen) writeVariants(config *Config, swagger *spec.Swagger) error {
+	if err := g.writeOutputs(config, swagger); err != nil {
+		return err
+	}
+
+	for _, visibility := range config.Visibilities {
+		visibility = strings.ToLower(strings.TrimSpace(visibility))
+
+		filtered, err := swag.FilterVisibility(swagger, visibility)
+		if err != nil {
+			return err
+		}
+
+		variantConfig := *config
+		variantConfig.InstanceName = config.InstanceName + "_" + visibility
+		variantConfig.definitionsOf = ""
+
+		if config.derived != nil {
+			variantConfig.derived = &derivedDoc{instanceName: config.InstanceName, method: "View", args: []string{visibility}}
+		}
+
+		if err := g.writeOutputs(&variantConfig, filtered); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitBuildTags returns the build tags of a comma separated list.
+func splitBuildTags(tags string) []string {
+	var buildTags []string
+
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			buildTags = append(buildTags, tag)
+		}
+	}
+
+	return buildTags
+}
+
+// versionInstanceName returns the instance name of a version, which names Go identifiers of docs.go,
+// eg: the version v1.1 of the swagger instance gives swagger_v1_1.
+func versionInstanceName(instanceName, version string) string {
+	return instanceName + "_" + nonIdentifierCharacters.ReplaceAllString(version, "_")
+}
+
+// versionBasePath returns the name and the base path of a version, given as name or name=basePath.
+// The base path defaults to basePath with {version} replaced by the name, or followed by the name.
+func versionBasePath(basePath, version string) (string, string) {
+	if name, versionBasePath, ok := strings.Cut(version, "="); ok {
+		return strings.TrimSpace(name), strings.TrimSpace(versionBasePath)
+	}
+
+	version = strings.TrimSpace(version)
+
+	if strings.Contains(basePath, "{version}") {
+		return version, strings.ReplaceAll(basePath, "{version}", version)
+	}
+
+	return version, path.Join("/", basePath, version)
+}
+
+func (g *Gen) pruneDefinitions(config *Config, swagger *spec.Swagger) error {
+	var keep []string
+	for _, pattern := range config.KeepDefinitions {
+		keep = append(keep, strings.TrimSpace(pattern))
+	}
+
+	report, err := swag.PruneDefinitions(swagger, keep)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range report.Pruned {
+		g.debug.Printf("prune definition %s: not referenced", name)
+	}
+
+	kept := make([]string, 0, len(report.Kept))
+	for name := range report.Kept {
+		kept = append(kept, name)
+	}
+
+	sort.Strings(kept)
+
+	for _, name := range kept {
+		g.debug.Printf("keep definition %s: %s", name, report.Kept[name])
+	}
+
+	return nil
+}
+
+func (g *Gen) writeOutputs(config *Config, swagger *spec.Swagger) error {
 	for _, outputType := range config.OutputTypes {
 		outputType = strings.ToLower(strings.TrimSpace(outputType))
 		if typeWriter, ok := g.outputTypeMap[outputType]; ok {
@@ -242,15 +534,7 @@ func (g *Gen) Build(config *Config) error {
 }
 
 func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "docs.go"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
-	}
-
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
+	filename := outputFilename(config, "docs.go")
 
 	docFileName := path.Join(config.OutputDir, filename)
 
@@ -267,14 +551,20 @@ func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
 		packageName = strings.ReplaceAll(packageName, "-", "_")
 	}
 
-	docs, err := os.Create(docFileName)
+	var docs bytes.Buffer
+
+	// Write doc
+	if config.derived != nil {
+		err = g.writeDerivedGoDoc(packageName, &docs, config)
+	} else {
+		err = g.writeGoDoc(packageName, &docs, swagger, config)
+	}
+
 	if err != nil {
 		return err
 	}
-	defer docs.Close()
 
-	// Write doc
-	err = g.writeGoDoc(packageName, docs, swagger, config)
+	err = g.writeFile(docs.Bytes(), docFileName)
 	if err != nil {
 		return err
 	}
@@ -285,15 +575,11 @@ func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
 }
 
 func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.json"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
+	if config.SplitDefinitions != "" {
+		return g.writeSplitSwagger(config, swagger, "swagger.json", g.jsonIndent)
 	}
 
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
+	filename := outputFilename(config, "swagger.json")
 
 	jsonFileName := path.Join(config.OutputDir, filename)
 
@@ -313,28 +599,19 @@ func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
 }
 
 func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.yaml"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
+	if config.SplitDefinitions != "" {
+		return g.writeSplitSwagger(config, swagger, "swagger.yaml", g.yamlIndent)
 	}
 
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
+	filename := outputFilename(config, "swagger.yaml")
 
 	yamlFileName := path.Join(config.OutputDir, filename)
 
-	b, err := g.json(swagger)
+	y, err := g.yamlIndent(swagger)
 	if err != nil {
 		return err
 	}
 
-	y, err := g.jsonToYAML(b)
-	if err != nil {
-		return fmt.Errorf("cannot covert json to yaml error: %s", err)
-	}
-
 	err = g.writeFile(y, yamlFileName)
 	if err != nil {
 		return err
@@ -345,7 +622,41 @@ func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
 	return nil
 }
 
+// yamlIndent encodes data as YAML through its JSON encoding.
+func (g *Gen) yamlIndent(data interface{}) ([]byte, error) {
+	b, err := g.json(data)
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := g.jsonToYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot covert json to yaml error: %s", err)
+	}
+
+	return y, nil
+}
+
+// outputFilename prefixes filename with the state and instance name of config, if any.
+func outputFilename(config *Config, filename string) string {
+	if config.State != "" {
+		filename = config.State + "_" + filename
+	}
+
+	if config.InstanceName != swag.Name {
+		filename = config.InstanceName + "_" + filename
+	}
+
+	return filename
+}
+
 func (g *Gen) writeFile(b []byte, file string) error {
+	if g.generated != nil {
+		g.generated[filepath.Clean(file)] = b
+
+		return nil
+	}
+
 	f, err := os.Create(file)
 	if err != nil {
 		return err
@@ -462,10 +773,7 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 		return err
 	}
 
-	state := ""
-	if len(config.State) > 0 {
-		state = cases.Title(language.English).String(strings.ToLower(config.State))
-	}
+	state := docState(config)
 
 	buffer := &bytes.Buffer{}
 
@@ -512,6 +820,84 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 	return err
 }
 
+// writeDerivedGoDoc writes the docs.go file of the document config.derived derives from the one
+// of another instance.
+func (g *Gen) writeDerivedGoDoc(packageName string, output io.Writer, config *Config) error {
+	generator, err := template.New("swagger_info").Parse(derivedPackageTemplate)
+	if err != nil {
+		return err
+	}
+
+	state := docState(config)
+
+	args := make([]string, 0, len(config.derived.args))
+	for _, arg := range config.derived.args {
+		args = append(args, strconv.Quote(arg))
+	}
+
+	buffer := &bytes.Buffer{}
+
+	err = generator.Execute(buffer, struct {
+		Timestamp     time.Time
+		GeneratedTime bool
+		PackageName   string
+		InstanceName  string
+		Name          string
+		Source        string
+		Method        string
+		Args          string
+	}{
+		Timestamp:     time.Now(),
+		GeneratedTime: config.GeneratedTime,
+		PackageName:   packageName,
+		InstanceName:  config.InstanceName,
+		Name:          docVariable(state, config.InstanceName),
+		Source:        docVariable(state, config.derived.instanceName),
+		Method:        config.derived.method,
+		Args:          strings.Join(args, ", "),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = output.Write(g.formatSource(buffer.Bytes()))
+
+	return err
+}
+
+// docState returns the state naming the variables of docs.go.
+func docState(config *Config) string {
+	if len(config.State) == 0 {
+		return ""
+	}
+
+	return cases.Title(language.English).String(strings.ToLower(config.State))
+}
+
+// docVariable returns the name of the swag.Spec variable of docs.go for state and instanceName,
+// the one of packageTemplate.
+func docVariable(state, instanceName string) string {
+	if instanceName == swag.Name {
+		return "Swagger" + state + "Info"
+	}
+
+	return "Swagger" + state + "Info" + instanceName
+}
+
+var derivedPackageTemplate = `// Package {{.PackageName}} Code generated by swaggo/swag{{ if .GeneratedTime }} at {{ .Timestamp }}{{ end }}. DO NOT EDIT
+package {{.PackageName}}
+
+import "github.com/swaggo/swag"
+
+// {{ .Name }} holds exported Swagger Info so clients can modify it, derived from {{ .Source }} when the package is initialized
+var {{ .Name }} = swag.Must({{ .Source }}.{{ .Method }}({{ .Args }}))
+
+func init() {
+	{{ .Name }}.InfoInstanceName = {{ printf "%q" .InstanceName }}
+	swag.Register({{ .Name }}.InstanceName(), {{ .Name }})
+}
+`
+
 var packageTemplate = `// Package {{.PackageName}} Code generated by swaggo/swag{{ if .GeneratedTime }} at {{ .Timestamp }}{{ end }}. DO NOT EDIT
 package {{.PackageName}}
 
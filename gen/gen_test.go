@@ -153,6 +153,137 @@ func TestGen_BuildInstanceName(t *testing.T) {
 	}
 }
 
+func TestGen_BuildVisibilities(t *testing.T) {
+	config := &Config{
+		SearchDir:    "../testdata/visibility",
+		MainAPIFile:  "./main.go",
+		OutputDir:    "../testdata/visibility/docs",
+		OutputTypes:  []string{"json"},
+		Visibilities: []string{"public", "internal"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	var public, internal spec.Swagger
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger_public_swagger.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &public))
+	assert.Len(t, public.Paths.Paths, 1)
+	assert.NotContains(t, public.Definitions, "main.Metrics")
+	assert.NotContains(t, public.Definitions["main.User"].Properties, "email")
+	assert.NotContains(t, public.Definitions["main.User"].Properties, "token")
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger_internal_swagger.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &internal))
+	assert.Len(t, internal.Paths.Paths, 2)
+	assert.Contains(t, internal.Definitions, "main.Metrics")
+	assert.Contains(t, internal.Definitions["main.User"].Properties, "token")
+
+	assert.FileExists(t, filepath.Join(config.OutputDir, "swagger.json"))
+
+	config.Visibilities = []string{"secret"}
+	assert.EqualError(t, New().Build(config), "invalid visibility: secret")
+}
+
+func TestGen_BuildPruneDefinitions(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := &Config{
+		SearchDir:        "../testdata/visibility",
+		MainAPIFile:      "./main.go",
+		OutputDir:        "../testdata/visibility/docs",
+		OutputTypes:      []string{"json"},
+		PruneDefinitions: true,
+		Debugger:         log.New(&buf, "", 0),
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	assert.Contains(t, buf.String(), "keep definition main.Metrics: referenced by GET /metrics\n")
+	assert.Contains(t, buf.String(), "keep definition main.User: referenced by GET /users/{id}\n")
+
+	config.KeepDefinitions = []string{"[main"}
+	assert.Error(t, New().Build(config))
+}
+
+func TestGen_BuildVersions(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../testdata/versions",
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/versions/docs",
+		OutputTypes: []string{"go", "json"},
+		Versions:    []string{"v1", "v2=/v2", "v1.1"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	var v1, v2 spec.Swagger
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger_v1_swagger.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &v1))
+	assert.Equal(t, "/api/v1", v1.BasePath)
+	assert.Equal(t, "v1", v1.Info.Version)
+	assert.Len(t, v1.Paths.Paths, 1)
+	assert.NotContains(t, v1.Definitions, "main.Order")
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger_v2_swagger.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &v2))
+	assert.Equal(t, "/v2", v2.BasePath)
+	assert.Equal(t, "v2", v2.Info.Version)
+	assert.Len(t, v2.Paths.Paths, 2)
+	assert.Contains(t, v2.Definitions, "main.Order")
+
+	// the base document has every operation
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"x-versions"`)
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "docs.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "var SwaggerInfo = &swag.Spec{")
+
+	// the documents of the versions are derived from the base one instead of repeating its definitions
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger_v2_docs.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `var SwaggerInfoswagger_v2 = swag.Must(SwaggerInfo.VersionView("v2", "/v2"))`)
+	assert.Contains(t, string(b), `SwaggerInfoswagger_v2.InfoInstanceName = "swagger_v2"`)
+	assert.NotContains(t, string(b), "main.User")
+
+	// a dotted version names valid identifiers
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger_v1_1_docs.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `var SwaggerInfoswagger_v1_1 = swag.Must(SwaggerInfo.VersionView("v1.1", "/api/v1.1"))`)
+
+	cmd := exec.Command("go", "build", "./docs")
+	cmd.Dir = config.SearchDir
+
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+
+	// the split definitions of the versions are the ones of the base document
+	config.SplitDefinitions = SplitByType
+	assert.NoError(t, New().Build(config))
+	assert.DirExists(t, filepath.Join(config.OutputDir, "definitions"))
+	assert.NoDirExists(t, filepath.Join(config.OutputDir, "swagger_v2_definitions"))
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger_v2_swagger.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"$ref": "definitions/`)
+
+	config.Versions = []string{"v1.1", "v1_1"}
+	assert.EqualError(t, New().Build(config), "invalid or duplicated version: v1_1")
+
+	config.Versions = []string{"v1", "v1=/v1"}
+	assert.EqualError(t, New().Build(config), "invalid or duplicated version: v1=/v1")
+}
+
 func TestGen_BuildSnakeCase(t *testing.T) {
 	config := &Config{
 		SearchDir:          "../testdata/simple2",
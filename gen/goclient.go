@@ -0,0 +1,827 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// goClientStdImports the standard packages the generated client may import.
+var goClientStdImports = []string{
+	"bytes",
+	"context",
+	"encoding/json",
+	"encoding/xml",
+	"fmt",
+	"io",
+	"mime/multipart",
+	"net/http",
+	"net/url",
+	"strings",
+}
+
+// goClientReservedNames the names model packages can't be imported as, since the generated code uses them.
+var goClientReservedNames = map[string]bool{
+	"bytes": true, "context": true, "json": true, "xml": true, "fmt": true, "io": true, "multipart": true,
+	"http": true, "url": true, "strings": true, "c": true, "ctx": true, "params": true, "path": true,
+	"query": true, "header": true, "body": true, "contentType": true, "resp": true, "result": true,
+	"err": true, "b": true, "s": true, "v": true, "values": true, "form": true, "buf": true, "mw": true,
+	"fw": true, "client": true,
+}
+
+// goInitialisms the words of identifiers written in upper case, as golint would have them.
+var goInitialisms = map[string]string{
+	"api": "API", "html": "HTML", "http": "HTTP", "id": "ID", "ip": "IP", "json": "JSON",
+	"uri": "URI", "url": "URL", "uuid": "UUID", "xml": "XML",
+}
+
+var nonIdentifierCharacters = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// collectionSeparators the separators of the collection formats of array parameters, multi excepted.
+var collectionSeparators = map[string]string{
+	"":      ",",
+	"csv":   ",",
+	"ssv":   " ",
+	"tsv":   "\t",
+	"pipes": "|",
+}
+
+func (g *Gen) writeGoClient(config *Config, swagger *spec.Swagger) error {
+	dir := path.Join(config.OutputDir, outputFilename(config, "client"))
+
+	err := g.mkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	packageName := nonIdentifierCharacters.ReplaceAllString(path.Base(dir), "_")
+
+	clientFileName := path.Join(dir, "client.go")
+
+	err = g.writeFile(g.formatSource(buildGoClient(swagger, g.definitionGoTypes, packageName)), clientFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create client.go at %+v", clientFileName)
+
+	return nil
+}
+
+// goClientBuilder writes a Go client of a swagger document, referring to definitions by
+// the Go types they are generated from.
+type goClientBuilder struct {
+	swagger *spec.Swagger
+	goTypes map[string]swag.GoType
+
+	// imports the names of the imported packages, by path
+	imports map[string]string
+	// importNames the imported packages names in use
+	importNames map[string]bool
+	// methods the names of the methods in use
+	methods map[string]bool
+
+	buf bytes.Buffer
+}
+
+// buildGoClient returns the source of a Go client package, with one method per operation of swagger.
+func buildGoClient(swagger *spec.Swagger, goTypes map[string]swag.GoType, packageName string) []byte {
+	b := &goClientBuilder{
+		swagger:     swagger,
+		goTypes:     goTypes,
+		imports:     make(map[string]string),
+		importNames: make(map[string]bool),
+		methods:     make(map[string]bool),
+	}
+
+	for _, importPath := range []string{"bytes", "context", "fmt", "io", "net/http", "net/url", "strings"} {
+		b.use(importPath)
+	}
+
+	b.buf.WriteString(goClientScaffold)
+
+	for _, op := range listOperations(swagger) {
+		b.writeOperation(op)
+	}
+
+	var file bytes.Buffer
+
+	fmt.Fprintf(&file, "// Package %s Code generated by swaggo/swag. DO NOT EDIT\n", packageName)
+	fmt.Fprintf(&file, "package %s\n\nimport (\n", packageName)
+
+	for _, importPath := range goClientStdImports {
+		if _, ok := b.imports[importPath]; ok {
+			fmt.Fprintf(&file, "\t%q\n", importPath)
+		}
+	}
+
+	paths := make([]string, 0, len(b.imports))
+	for importPath := range b.imports {
+		if !containsString(goClientStdImports, importPath) {
+			paths = append(paths, importPath)
+		}
+	}
+
+	sort.Strings(paths)
+
+	if len(paths) > 0 {
+		file.WriteString("\n")
+	}
+
+	for _, importPath := range paths {
+		if name := b.imports[importPath]; name != path.Base(importPath) {
+			fmt.Fprintf(&file, "\t%s %q\n", name, importPath)
+		} else {
+			fmt.Fprintf(&file, "\t%q\n", importPath)
+		}
+	}
+
+	file.WriteString(")\n")
+	file.Write(b.buf.Bytes())
+
+	return file.Bytes()
+}
+
+// use imports the standard package importPath.
+func (b *goClientBuilder) use(importPath string) {
+	b.imports[importPath] = path.Base(importPath)
+}
+
+// importName returns the name goType's package is imported as, importing it if needed.
+func (b *goClientBuilder) importName(goType swag.GoType) string {
+	if name, ok := b.imports[goType.PkgPath]; ok {
+		return name
+	}
+
+	name := goType.Package
+	for i := 2; goClientReservedNames[name] || b.importNames[name]; i++ {
+		name = goType.Package + strconv.Itoa(i)
+	}
+
+	b.imports[goType.PkgPath] = name
+	b.importNames[name] = true
+
+	return name
+}
+
+// methodName returns the name of the method of op, from its ID, or its method and path if it has none.
+func (b *goClientBuilder) methodName(op pathOperation) string {
+	name := goIdentifier(op.Operation.ID)
+	if name == "" {
+		name = goIdentifier(strings.ToLower(op.Method) + " " + op.Path)
+	}
+
+	unique := name
+	for i := 2; b.methods[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	b.methods[unique] = true
+
+	return unique
+}
+
+// schemaType returns the Go type of values of schema. Definitions are referred to by the Go type they are
+// generated from, json.RawMessage is used for the schemas which have none, like composed objects.
+func (b *goClientBuilder) schemaType(schema *spec.Schema) string {
+	if schema == nil {
+		return b.rawMessage()
+	}
+
+	if ref := schema.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		if goType, ok := b.goTypes[name]; ok {
+			return b.importName(goType) + "." + goType.Name
+		}
+
+		definition, ok := b.swagger.Definitions[name]
+		if ok && definition.Ref.String() == "" && len(definition.Type) > 0 &&
+			!definition.Type.Contains(swag.OBJECT) && !definition.Type.Contains(swag.ARRAY) {
+			return b.schemaType(&definition)
+		}
+
+		return b.rawMessage()
+	}
+
+	if len(schema.AllOf) == 1 {
+		return b.schemaType(&schema.AllOf[0])
+	}
+
+	if len(schema.AllOf) > 1 {
+		return b.rawMessage()
+	}
+
+	switch {
+	case schema.Type.Contains(swag.ARRAY):
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "[]interface{}"
+		}
+
+		return "[]" + b.schemaType(schema.Items.Schema)
+	case schema.Type.Contains(swag.STRING):
+		return swag.STRING
+	case schema.Type.Contains(swag.INTEGER), schema.Type.Contains(swag.NUMBER):
+		return numberType(schema.Type.Contains(swag.INTEGER), schema.Format)
+	case schema.Type.Contains(swag.BOOLEAN):
+		return "bool"
+	case len(schema.Properties) > 0:
+		return b.rawMessage()
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		return "map[string]" + b.schemaType(schema.AdditionalProperties.Schema)
+	case schema.Type.Contains(swag.OBJECT):
+		return "map[string]interface{}"
+	}
+
+	return "interface{}"
+}
+
+func (b *goClientBuilder) rawMessage() string {
+	b.use("encoding/json")
+
+	return "json.RawMessage"
+}
+
+// parameterType returns the Go type of the values of a non body parameter.
+func parameterType(paramType, format string, items *spec.Items) string {
+	switch paramType {
+	case swag.ARRAY:
+		if items == nil {
+			return "[]string"
+		}
+
+		return "[]" + parameterType(items.Type, items.Format, items.Items)
+	case swag.INTEGER, swag.NUMBER:
+		return numberType(paramType == swag.INTEGER, format)
+	case swag.BOOLEAN:
+		return "bool"
+	case "file":
+		return "io.Reader"
+	}
+
+	return swag.STRING
+}
+
+func numberType(integer bool, format string) string {
+	if integer {
+		switch format {
+		case "int32", "int64":
+			return format
+		}
+
+		return "int"
+	}
+
+	if format == "float" {
+		return "float32"
+	}
+
+	return "float64"
+}
+
+// nillable reports whether the zero value of goType is nil, so it needs no pointer to be optional.
+func nillable(goType string) bool {
+	for _, prefix := range []string{"*", "[]", "map[", "interface{}", "json.RawMessage", "io.Reader"} {
+		if strings.HasPrefix(goType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// goIdentifier converts name to an exported Go identifier, eg: "get-account_id" gives "GetAccountID".
+func goIdentifier(name string) string {
+	var identifier strings.Builder
+
+	for _, word := range nonIdentifierCharacters.Split(name, -1) {
+		if word == "" {
+			continue
+		}
+
+		if initialism, ok := goInitialisms[strings.ToLower(word)]; ok {
+			identifier.WriteString(initialism)
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		identifier.WriteString(string(runes))
+	}
+
+	result := identifier.String()
+	if result != "" && !unicode.IsLetter([]rune(result)[0]) {
+		result = "N" + result
+	}
+
+	return result
+}
+
+// mediaEncoding returns the media type of mediaTypes a client should use, with the encoding of its values:
+// json, xml or text. JSON is preferred.
+func mediaEncoding(mediaTypes []string) (string, string) {
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, "json") {
+			return mediaType, "json"
+		}
+	}
+
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, "xml") {
+			return mediaType, "xml"
+		}
+	}
+
+	for _, mediaType := range mediaTypes {
+		if strings.HasPrefix(mediaType, "text/") {
+			return mediaType, "text"
+		}
+	}
+
+	return "application/json", "json"
+}
+
+// goClientField a field of the parameters of an operation.
+type goClientField struct {
+	Name  string
+	Type  string
+	Param *spec.Parameter
+}
+
+func (b *goClientBuilder) writeOperation(op pathOperation) {
+	name := b.methodName(op)
+
+	consumes := op.Operation.Consumes
+	if len(consumes) == 0 {
+		consumes = b.swagger.Consumes
+	}
+
+	produces := op.Operation.Produces
+	if len(produces) == 0 {
+		produces = b.swagger.Produces
+	}
+
+	fields := b.parameterFields(op.Operation)
+
+	if len(fields) > 0 {
+		fmt.Fprintf(&b.buf, "\n// %sParams the parameters of %s.\ntype %sParams struct {\n", name, name, name)
+
+		for _, field := range fields {
+			writeComment(&b.buf, "\t", field.Name+" "+field.Param.Description)
+			fmt.Fprintf(&b.buf, "\t%s %s\n", field.Name, field.Type)
+		}
+
+		b.buf.WriteString("}\n")
+	}
+
+	responseFields := b.responseFields(op.Operation)
+
+	fmt.Fprintf(&b.buf, "\n// %sResponse the response of %s, decoded into the field of its status code.\n", name, name)
+	fmt.Fprintf(&b.buf, "type %sResponse struct {\n\tStatusCode int\n\tHeader http.Header\n", name)
+
+	for _, field := range responseFields {
+		if field.Type == "" {
+			continue
+		}
+
+		writeComment(&b.buf, "\t", field.Name+" "+field.Description)
+		fmt.Fprintf(&b.buf, "\t%s %s\n", field.Name, field.Type)
+	}
+
+	b.buf.WriteString("}\n\n")
+
+	summary := op.Operation.Summary
+	if summary == "" {
+		summary = "calls " + op.Method + " " + op.Path
+	}
+
+	writeComment(&b.buf, "", name+" "+summary)
+
+	if op.Operation.Description != "" && op.Operation.Description != op.Operation.Summary {
+		b.buf.WriteString("//\n")
+		writeComment(&b.buf, "", op.Operation.Description)
+	}
+
+	fmt.Fprintf(&b.buf, "//\n// %s %s\n", op.Method, op.Path)
+
+	if op.Operation.Deprecated {
+		b.buf.WriteString("//\n// Deprecated: the operation is deprecated.\n")
+	}
+
+	if len(fields) > 0 {
+		fmt.Fprintf(&b.buf, "func (c *Client) %s(ctx context.Context, params *%sParams) (*%sResponse, error) {\n", name, name, name)
+		fmt.Fprintf(&b.buf, "if params == nil {\nparams = &%sParams{}\n}\n\n", name)
+	} else {
+		fmt.Fprintf(&b.buf, "func (c *Client) %s(ctx context.Context) (*%sResponse, error) {\n", name, name)
+	}
+
+	b.writeRequest(op, fields, consumes, produces)
+
+	fmt.Fprintf(&b.buf, "\nresp, err := c.do(ctx, %q, path, query, header, contentType, body)\n", op.Method)
+	b.buf.WriteString("if err != nil {\nreturn nil, err\n}\n\ndefer resp.Body.Close()\n\n")
+	fmt.Fprintf(&b.buf, "result := &%sResponse{StatusCode: resp.StatusCode, Header: resp.Header}\n\n", name)
+
+	b.writeDecoding(responseFields, produces)
+
+	b.buf.WriteString("\nreturn result, nil\n}\n")
+}
+
+// parameterFields returns the fields of the parameters of op, optional parameters are pointers.
+func (b *goClientBuilder) parameterFields(op *spec.Operation) []goClientField {
+	var fields []goClientField
+
+	names := make(map[string]bool)
+
+	for i := range op.Parameters {
+		param := &op.Parameters[i]
+
+		var (
+			fieldName string
+			fieldType string
+		)
+
+		if param.In == "body" {
+			fieldName = "Body"
+			fieldType = b.schemaType(param.Schema)
+		} else {
+			fieldName = goIdentifier(param.Name)
+			fieldType = parameterType(param.Type, param.Format, param.Items)
+		}
+
+		if fieldName == "" {
+			fieldName = "Param"
+		}
+
+		if names[fieldName] {
+			fieldName += goIdentifier(param.In)
+		}
+
+		for unique, i := fieldName, 2; names[fieldName]; i++ {
+			fieldName = unique + strconv.Itoa(i)
+		}
+
+		names[fieldName] = true
+
+		if !param.Required && !nillable(fieldType) {
+			fieldType = "*" + fieldType
+		}
+
+		fields = append(fields, goClientField{Name: fieldName, Type: fieldType, Param: param})
+	}
+
+	return fields
+}
+
+// goClientResponse a field of the response of an operation.
+type goClientResponse struct {
+	// Code the status code, 0 for the default response
+	Code        int
+	Name        string
+	Type        string
+	Description string
+}
+
+func (b *goClientBuilder) responseFields(op *spec.Operation) []goClientResponse {
+	if op.Responses == nil {
+		return nil
+	}
+
+	var fields []goClientResponse
+
+	add := func(code int, name string, response *spec.Response) {
+		field := goClientResponse{Code: code, Name: name, Description: response.Description}
+
+		if response.Schema != nil {
+			field.Type = b.schemaType(response.Schema)
+			if !nillable(field.Type) {
+				field.Type = "*" + field.Type
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	for _, code := range sortedResponseCodes(op.Responses) {
+		response := op.Responses.StatusCodeResponses[code]
+		add(code, "Status"+strconv.Itoa(code), &response)
+	}
+
+	if op.Responses.Default != nil {
+		add(0, "Default", op.Responses.Default)
+	}
+
+	return fields
+}
+
+// writeRequest writes the statements building the path, query, header, content type and body of a request.
+func (b *goClientBuilder) writeRequest(op pathOperation, fields []goClientField, consumes, produces []string) {
+	b.buf.WriteString("path := " + b.pathExpression(op.Path, fields) + "\n")
+	b.buf.WriteString("query := url.Values{}\nheader := http.Header{}\n")
+
+	if len(produces) > 0 {
+		fmt.Fprintf(&b.buf, "header.Set(\"Accept\", %q)\n", strings.Join(produces, ", "))
+	}
+
+	var (
+		form      []goClientField
+		multipart bool
+		bodyField *goClientField
+	)
+
+	for i := range fields {
+		field := fields[i]
+
+		switch field.Param.In {
+		case "query":
+			writeParameterValue(&b.buf, field, "query.Set(%q, %s)", "query.Add(%q, %s)")
+		case "header":
+			writeParameterValue(&b.buf, field, "header.Set(%q, %s)", "header.Add(%q, %s)")
+		case "formData":
+			form = append(form, field)
+			multipart = multipart || field.Param.Type == "file"
+		case "body":
+			bodyField = &fields[i]
+		}
+	}
+
+	b.buf.WriteString("\nvar body io.Reader\n\ncontentType := \"\"\n")
+
+	switch {
+	case len(form) > 0 && (multipart || containsString(consumes, "multipart/form-data")):
+		b.use("mime/multipart")
+		b.buf.WriteString("\nvar buf bytes.Buffer\n\nmw := multipart.NewWriter(&buf)\n")
+
+		for _, field := range form {
+			if field.Param.Type == "file" {
+				fmt.Fprintf(&b.buf, "\nif params.%s != nil {\n", field.Name)
+				fmt.Fprintf(&b.buf, "fw, err := mw.CreateFormFile(%q, %q)\n", field.Param.Name, field.Param.Name)
+				b.buf.WriteString("if err != nil {\nreturn nil, err\n}\n\n")
+				fmt.Fprintf(&b.buf, "if _, err := io.Copy(fw, params.%s); err != nil {\nreturn nil, err\n}\n}\n", field.Name)
+
+				continue
+			}
+
+			write := "if err := mw.WriteField(%q, %s); err != nil {\nreturn nil, err\n}"
+			writeParameterValue(&b.buf, field, write, write)
+		}
+
+		b.buf.WriteString("\nif err := mw.Close(); err != nil {\nreturn nil, err\n}\n\n")
+		b.buf.WriteString("body = &buf\ncontentType = mw.FormDataContentType()\n")
+	case len(form) > 0:
+		b.buf.WriteString("\nform := url.Values{}\n")
+
+		for _, field := range form {
+			writeParameterValue(&b.buf, field, "form.Set(%q, %s)", "form.Add(%q, %s)")
+		}
+
+		b.buf.WriteString("\nbody = strings.NewReader(form.Encode())\ncontentType = \"application/x-www-form-urlencoded\"\n")
+	case bodyField != nil:
+		mediaType, encoding := mediaEncoding(consumes)
+		if encoding == "text" && strings.TrimPrefix(bodyField.Type, "*") != swag.STRING {
+			mediaType, encoding = "application/json", "json"
+		}
+
+		value := "params.Body"
+		if strings.HasPrefix(bodyField.Type, "*") {
+			value = "*params.Body"
+		}
+
+		nillable := nillable(bodyField.Type)
+		if nillable {
+			b.buf.WriteString("\nif params.Body != nil {\n")
+		} else {
+			b.buf.WriteString("\n{\n")
+		}
+
+		switch encoding {
+		case "text":
+			fmt.Fprintf(&b.buf, "body = strings.NewReader(%s)\n", value)
+		default:
+			b.use("encoding/" + encoding)
+			fmt.Fprintf(&b.buf, "b, err := %s.Marshal(params.Body)\nif err != nil {\nreturn nil, err\n}\n\nbody = bytes.NewReader(b)\n", encoding)
+		}
+
+		fmt.Fprintf(&b.buf, "contentType = %q\n}\n", mediaType)
+	}
+}
+
+// pathExpression returns the expression of the path of an operation, with its parameters escaped.
+func (b *goClientBuilder) pathExpression(operationPath string, fields []goClientField) string {
+	var parts []string
+
+	last := 0
+
+	for _, match := range pathParamPattern.FindAllStringSubmatchIndex(operationPath, -1) {
+		var field *goClientField
+
+		for i := range fields {
+			if fields[i].Param.In == "path" && fields[i].Param.Name == operationPath[match[2]:match[3]] {
+				field = &fields[i]
+			}
+		}
+
+		if field == nil {
+			continue
+		}
+
+		if match[0] > last {
+			parts = append(parts, strconv.Quote(operationPath[last:match[0]]))
+		}
+
+		parts = append(parts, "url.PathEscape("+formatExpression(field.Type, "params."+field.Name)+")")
+		last = match[1]
+	}
+
+	if last < len(operationPath) || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(operationPath[last:]))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// formatExpression returns the expression formatting the value of a parameter of type goType.
+func formatExpression(goType, value string) string {
+	if strings.HasPrefix(goType, "*") {
+		goType = goType[1:]
+		value = "*" + value
+	}
+
+	if goType == swag.STRING {
+		return value
+	}
+
+	return "fmt.Sprint(" + value + ")"
+}
+
+// writeParameterValue writes the statement setting the value of the parameter of field with set,
+// an array parameter of collection format multi is set once per value with add.
+func writeParameterValue(buf *bytes.Buffer, field goClientField, set, add string) {
+	name := field.Param.Name
+	value := "params." + field.Name
+
+	switch {
+	case strings.HasPrefix(field.Type, "[]"):
+		fmt.Fprintf(buf, "\nif len(%s) > 0 {\n", value)
+
+		if field.Param.CollectionFormat == "multi" {
+			fmt.Fprintf(buf, "for _, v := range %s {\n", value)
+			fmt.Fprintf(buf, add+"\n}\n}\n", name, formatExpression(field.Type[2:], "v"))
+
+			return
+		}
+
+		fmt.Fprintf(buf, "values := make([]string, 0, len(%s))\nfor _, v := range %s {\n", value, value)
+		fmt.Fprintf(buf, "values = append(values, %s)\n}\n\n", formatExpression(field.Type[2:], "v"))
+		fmt.Fprintf(buf, set+"\n}\n", name, fmt.Sprintf("strings.Join(values, %q)", collectionSeparators[field.Param.CollectionFormat]))
+	case strings.HasPrefix(field.Type, "*"):
+		fmt.Fprintf(buf, "\nif %s != nil {\n", value)
+		fmt.Fprintf(buf, set+"\n}\n", name, formatExpression(field.Type, value))
+	default:
+		fmt.Fprintf(buf, set+"\n", name, formatExpression(field.Type, value))
+	}
+}
+
+// writeDecoding writes the switch decoding the body of a response into the field of its status code.
+func (b *goClientBuilder) writeDecoding(fields []goClientResponse, produces []string) {
+	_, encoding := mediaEncoding(produces)
+
+	b.buf.WriteString("switch resp.StatusCode {\n")
+
+	var defaultField *goClientResponse
+
+	for i := range fields {
+		if fields[i].Code == 0 {
+			defaultField = &fields[i]
+
+			continue
+		}
+
+		fmt.Fprintf(&b.buf, "case %d:\n", fields[i].Code)
+		b.writeDecode(fields[i], encoding)
+	}
+
+	b.buf.WriteString("default:\n")
+
+	if defaultField != nil {
+		b.writeDecode(*defaultField, encoding)
+	} else {
+		b.buf.WriteString("if resp.StatusCode >= http.StatusBadRequest {\nreturn nil, unexpectedStatus(resp)\n}\n")
+	}
+
+	b.buf.WriteString("}\n")
+}
+
+func (b *goClientBuilder) writeDecode(field goClientResponse, encoding string) {
+	if field.Type == "" {
+		return
+	}
+
+	target := "result." + field.Name
+
+	if encoding == "text" && field.Type == "*string" {
+		b.buf.WriteString("b, err := io.ReadAll(resp.Body)\nif err != nil {\nreturn nil, err\n}\n\n")
+		fmt.Fprintf(&b.buf, "s := string(b)\n%s = &s\n", target)
+
+		return
+	}
+
+	if encoding == "text" {
+		encoding = "json"
+	}
+
+	if strings.HasPrefix(field.Type, "*") {
+		fmt.Fprintf(&b.buf, "%s = new(%s)\n", target, field.Type[1:])
+	} else {
+		target = "&" + target
+	}
+
+	b.use("encoding/" + encoding)
+	fmt.Fprintf(&b.buf, "if err := %s.NewDecoder(resp.Body).Decode(%s); err != nil {\nreturn nil, err\n}\n", encoding, target)
+}
+
+// writeComment writes text as a comment, a line per line of text.
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(buf, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+const goClientScaffold = `
+// Client calls the operations of the API.
+type Client struct {
+	// BaseURL the URL the paths of the operations are relative to, eg: http://localhost:8080/api/v1
+	BaseURL string
+
+	// HTTPClient sends the requests, http.DefaultClient is used if nil
+	HTTPClient *http.Client
+
+	// RequestEditors are applied to every request before it is sent, eg: to add credentials
+	RequestEditors []func(req *http.Request) error
+}
+
+// New creates a Client of the API served at baseURL.
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Error is returned when the server answers with an error status code the operation does not declare.
+type Error struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, bytes.TrimSpace(e.Body))
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, contentType string, body io.Reader) (*http.Response, error) {
+	target := c.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for _, edit := range c.RequestEditors {
+		if err := edit(req); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(req)
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return &Error{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+}
+`
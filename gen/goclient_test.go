@@ -0,0 +1,129 @@
+package gen
+
+import (
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag"
+)
+
+func TestGoIdentifier(t *testing.T) {
+	assert.Equal(t, "GetAccountByID", goIdentifier("getAccountByID"))
+	assert.Equal(t, "GetAccountID", goIdentifier("get-account_id"))
+	assert.Equal(t, "XRequestID", goIdentifier("X-Request-ID"))
+	assert.Equal(t, "GetPetsID", goIdentifier("get /pets/{id}"))
+	assert.Equal(t, "N2fa", goIdentifier("2fa"))
+	assert.Equal(t, "", goIdentifier("{}"))
+}
+
+func TestBuildGoClient(t *testing.T) {
+	swagger := loadTestPetstore(t)
+	goTypes := map[string]swag.GoType{
+		"model.Pet": {PkgPath: "example.com/petstore/model", Package: "model", Name: "Pet"},
+	}
+
+	b, err := format.Source(buildGoClient(swagger, goTypes, "client"))
+	require.NoError(t, err)
+
+	src := string(b)
+
+	assert.Contains(t, src, "\t\"example.com/petstore/model\"\n")
+	assert.NotContains(t, src, `"encoding/xml"`)
+	assert.NotContains(t, src, `"mime/multipart"`)
+
+	// one method per operation, named by ID, or by method and path.
+	assert.Contains(t, src, "func (c *Client) AddPet(ctx context.Context, params *AddPetParams) (*AddPetResponse, error)")
+	assert.Contains(t, src, "func (c *Client) GetPet(ctx context.Context, params *GetPetParams) (*GetPetResponse, error)")
+	assert.Contains(t, src, "func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error)")
+	assert.Contains(t, src, "func (c *Client) PostLogin(ctx context.Context, params *PostLoginParams) (*PostLoginResponse, error)")
+
+	// body of the model type, optional parameters as pointers.
+	assert.Regexp(t, `Body\s+model\.Pet\n`, src)
+	assert.Regexp(t, `XRequestID\s+\*string\n`, src)
+	assert.Regexp(t, `ID\s+int\n`, src)
+	assert.Regexp(t, `Verbose\s+\*bool\n`, src)
+
+	assert.Contains(t, src, `path := "/pets/" + url.PathEscape(fmt.Sprint(params.ID))`)
+	assert.Contains(t, src, `query.Set("verbose", fmt.Sprint(*params.Verbose))`)
+	assert.Contains(t, src, `header.Set("X-Request-ID", *params.XRequestID)`)
+	assert.Contains(t, src, `body = strings.NewReader(form.Encode())`)
+	assert.Contains(t, src, `contentType = "application/json"`)
+	assert.Contains(t, src, "case 201:\n")
+	assert.Contains(t, src, "return nil, unexpectedStatus(resp)")
+}
+
+func TestBuildGoClient_Encodings(t *testing.T) {
+	swagger := loadTestPetstore(t)
+
+	addPet := swagger.Paths.Paths["/pets"].Post
+	addPet.Consumes = []string{"application/xml"}
+	addPet.Produces = []string{"application/xml"}
+	addPet.Responses.StatusCodeResponses[201] = spec.Response{
+		ResponseProps: spec.ResponseProps{Description: "Created", Schema: spec.RefSchema("#/definitions/model.Pet")},
+	}
+	addPet.Responses.Default = &spec.Response{
+		ResponseProps: spec.ResponseProps{Description: "Error", Schema: spec.StringProperty()},
+	}
+
+	login := swagger.Paths.Paths["/login"].Post
+	login.Parameters = append(login.Parameters, spec.Parameter{
+		ParamProps:   spec.ParamProps{Name: "avatar", In: "formData"},
+		SimpleSchema: spec.SimpleSchema{Type: "file"},
+	}, spec.Parameter{
+		ParamProps:   spec.ParamProps{Name: "roles", In: "formData"},
+		SimpleSchema: spec.SimpleSchema{Type: "array", Items: spec.NewItems().Typed("string", ""), CollectionFormat: "multi"},
+	})
+
+	b, err := format.Source(buildGoClient(swagger, nil, "client"))
+	require.NoError(t, err)
+
+	src := string(b)
+
+	// without its Go type, a definition is decoded as raw JSON.
+	assert.Regexp(t, `Body\s+json\.RawMessage\n`, src)
+	assert.Contains(t, src, `b, err := xml.Marshal(params.Body)`)
+	assert.Contains(t, src, `contentType = "application/xml"`)
+	assert.Contains(t, src, `header.Set("Accept", "application/xml")`)
+	assert.Contains(t, src, `if err := xml.NewDecoder(resp.Body).Decode(&result.Status201); err != nil {`)
+	assert.Contains(t, src, `if err := xml.NewDecoder(resp.Body).Decode(result.Default); err != nil {`)
+
+	assert.Contains(t, src, `mw := multipart.NewWriter(&buf)`)
+	assert.Contains(t, src, `fw, err := mw.CreateFormFile("avatar", "avatar")`)
+	assert.Contains(t, src, "for _, v := range params.Roles {\n\t\t\tif err := mw.WriteField(\"roles\", v); err != nil {")
+}
+
+func TestGen_BuildGoClient(t *testing.T) {
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/simple/docs",
+		OutputTypes: []string{"goclient"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	clientFile := filepath.Join(config.OutputDir, "client", "client.go")
+
+	src, err := os.ReadFile(clientFile)
+	require.NoError(t, err)
+
+	// models are imported, not generated.
+	assert.Contains(t, string(src), `"github.com/swaggo/swag/testdata/simple/web"`)
+	assert.NotContains(t, string(src), "type Pet struct")
+
+	goCMD, err := exec.LookPath("go")
+	require.NoError(t, err)
+
+	cmd := exec.Command(goCMD, "build", "./"+filepath.ToSlash(filepath.Dir(clientFile)))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	assert.NoError(t, cmd.Run())
+}
@@ -0,0 +1,194 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+func (g *Gen) writeHTTPRequests(config *Config, swagger *spec.Swagger) error {
+	tags, groups := groupOperationsByTag(swagger)
+	filenames := tagFilenames(tags)
+
+	for _, tag := range tags {
+		httpFileName := path.Join(config.OutputDir, outputFilename(config, filenames[tag]+".http"))
+
+		err := g.writeFile(buildHTTPRequests(swagger, groups[tag]), httpFileName)
+		if err != nil {
+			return err
+		}
+
+		g.debug.Printf("create %s at %+v", path.Base(httpFileName), httpFileName)
+	}
+
+	return nil
+}
+
+// buildHTTPRequests writes operations as a JetBrains/VS Code REST client file. Parameters and credentials
+// are variables declared at the top of the file, initialized with the examples of the parameters.
+// The optional query parameters are only sent by a commented out request line preceding the request.
+func buildHTTPRequests(swagger *spec.Swagger, operations []pathOperation) []byte {
+	variables := map[string]string{"baseUrl": baseURL(swagger)}
+
+	var requests bytes.Buffer
+
+	for _, op := range operations {
+		fmt.Fprintf(&requests, "\n### %s\n", op.Name())
+
+		if op.Operation.ID != "" {
+			fmt.Fprintf(&requests, "# @name %s\n", op.Operation.ID)
+		}
+
+		var (
+			query    []string
+			optional []string
+			headers  []string
+			form     []string
+			body     []byte
+		)
+
+		target := op.Path
+
+		for _, param := range op.Operation.Parameters {
+			param := param
+
+			if _, ok := variables[param.Name]; !ok && param.In != "body" {
+				declareVariable(variables, param.Name)
+
+				if example := parameterExample(&param); example != nil {
+					variables[param.Name] = formatValue(example)
+				}
+			}
+
+			switch param.In {
+			case "path":
+				target = strings.ReplaceAll(target, "{"+param.Name+"}", "{{"+param.Name+"}}")
+			case "query":
+				if param.Required {
+					query = append(query, url.QueryEscape(param.Name)+"={{"+param.Name+"}}")
+				} else {
+					optional = append(optional, url.QueryEscape(param.Name)+"={{"+param.Name+"}}")
+				}
+			case "header":
+				headers = append(headers, param.Name+": {{"+param.Name+"}}")
+			case "formData":
+				form = append(form, url.QueryEscape(param.Name)+"={{"+param.Name+"}}")
+			case "body":
+				body, _ = json.MarshalIndent(schemaExample(swagger, param.Schema), "", "  ")
+			}
+		}
+
+		security := operationSecurity(swagger, op.Operation)
+		if len(security) > 0 {
+			header, param := httpAuth(swagger, security[0], variables)
+			if header != "" {
+				headers = append(headers, header)
+			}
+
+			if param != "" {
+				query = append(query, param)
+			}
+		}
+
+		if len(optional) > 0 {
+			fmt.Fprintf(&requests, "# %s {{baseUrl}}%s?%s\n", op.Method, target, strings.Join(append(query, optional...), "&"))
+		}
+
+		if len(query) > 0 {
+			target += "?" + strings.Join(query, "&")
+		}
+
+		fmt.Fprintf(&requests, "%s {{baseUrl}}%s\n", op.Method, target)
+
+		switch {
+		case body != nil:
+			headers = append(headers, "Content-Type: application/json")
+		case len(form) > 0:
+			headers = append(headers, "Content-Type: application/x-www-form-urlencoded")
+			body = []byte(strings.Join(form, "&"))
+		}
+
+		for _, header := range headers {
+			requests.WriteString(header + "\n")
+		}
+
+		if body != nil {
+			requests.WriteString("\n")
+			requests.Write(body)
+			requests.WriteString("\n")
+		}
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		if name != "baseUrl" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var file bytes.Buffer
+
+	fmt.Fprintf(&file, "@baseUrl = %s\n", variables["baseUrl"])
+
+	for _, name := range names {
+		fmt.Fprintf(&file, "%s\n", strings.TrimSpace("@"+name+" = "+variables[name]))
+	}
+
+	file.Write(requests.Bytes())
+
+	return file.Bytes()
+}
+
+// httpAuth returns the header or the query parameter of the first security scheme of requirement,
+// declaring the variables holding the credentials.
+func httpAuth(swagger *spec.Swagger, requirement map[string][]string, variables map[string]string) (string, string) {
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		scheme, ok := swagger.SecurityDefinitions[name]
+		if !ok {
+			continue
+		}
+
+		switch scheme.Type {
+		case "basic":
+			declareVariable(variables, "username")
+			declareVariable(variables, "password")
+
+			return "Authorization: Basic {{username}} {{password}}", ""
+		case "apiKey":
+			declareVariable(variables, name)
+
+			if scheme.In == "query" {
+				return "", url.QueryEscape(scheme.Name) + "={{" + name + "}}"
+			}
+
+			return scheme.Name + ": {{" + name + "}}", ""
+		case "oauth2":
+			declareVariable(variables, "token")
+
+			return "Authorization: Bearer {{token}}", ""
+		}
+	}
+
+	return "", ""
+}
+
+func declareVariable(variables map[string]string, name string) {
+	if _, ok := variables[name]; !ok {
+		variables[name] = ""
+	}
+}
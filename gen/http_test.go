@@ -0,0 +1,67 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHTTPRequests(t *testing.T) {
+	swagger := loadTestPetstore(t)
+	tags, groups := groupOperationsByTag(swagger)
+	assert.Equal(t, []string{"user", "pet", ""}, tags)
+
+	assert.Equal(t, `@baseUrl = https://petstore.example.com/v1
+@ApiKey =
+@X-Request-ID =
+@id = 7
+@verbose =
+
+### Add a pet
+# @name addPet
+POST {{baseUrl}}/pets
+X-Request-ID: {{X-Request-ID}}
+X-API-Key: {{ApiKey}}
+Content-Type: application/json
+
+{
+  "name": "doggie",
+  "owner": {
+    "pets": []
+  },
+  "tags": [
+    "string"
+  ]
+}
+
+### GET /pets/{id}
+# @name getPet
+# GET {{baseUrl}}/pets/{{id}}?verbose={{verbose}}
+GET {{baseUrl}}/pets/{{id}}
+X-API-Key: {{ApiKey}}
+`, string(buildHTTPRequests(swagger, groups["pet"])))
+
+	assert.Equal(t, `@baseUrl = https://petstore.example.com/v1
+@name = admin
+@password =
+@username =
+
+### Login
+POST {{baseUrl}}/login
+Authorization: Basic {{username}} {{password}}
+Content-Type: application/x-www-form-urlencoded
+
+name={{name}}
+`, string(buildHTTPRequests(swagger, groups["user"])))
+
+	assert.Equal(t, "default", tagFilename(""))
+	assert.Equal(t, "pet_store", tagFilename("pet store"))
+
+	assert.Equal(t, map[string]string{
+		"pet store": "pet_store",
+		"Pet_Store": "Pet_Store_2",
+		"pet-store": "pet-store",
+		"":          "default",
+		"default":   "default_2",
+	}, tagFilenames([]string{"pet store", "Pet_Store", "pet-store", "", "default"}))
+}
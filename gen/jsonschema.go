@@ -0,0 +1,283 @@
+package gen
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// jsonSchemaDialect the JSON Schema draft the jsonschema output conforms to.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+func (g *Gen) writeJSONSchemas(config *Config, swagger *spec.Swagger) error {
+	definitions := make(spec.Definitions, len(swagger.Definitions)+len(g.jsonSchemaDefinitions))
+	for name, definition := range g.jsonSchemaDefinitions {
+		definitions[name] = definition
+	}
+
+	for name, definition := range swagger.Definitions {
+		definitions[name] = definition
+	}
+
+	roots := g.jsonSchemaRoots
+	if len(roots) == 0 {
+		for name := range swagger.Definitions {
+			roots = append(roots, name)
+		}
+	}
+
+	if config.JSONSchemaBundle {
+		bundle, err := buildJSONSchemaBundle(definitions, roots, config.JSONSchemaID)
+		if err != nil {
+			return err
+		}
+
+		b, err := g.jsonIndent(bundle)
+		if err != nil {
+			return err
+		}
+
+		bundleFileName := path.Join(config.OutputDir, outputFilename(config, "schema.json"))
+
+		err = g.writeFile(b, bundleFileName)
+		if err != nil {
+			return err
+		}
+
+		g.debug.Printf("create schema.json at %+v", bundleFileName)
+
+		return nil
+	}
+
+	schemas, err := buildJSONSchemas(definitions, roots, config.JSONSchemaID)
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join(config.OutputDir, outputFilename(config, "schemas"))
+
+	err = g.mkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedSchemaNames(schemas) {
+		b, err := g.jsonIndent(schemas[name])
+		if err != nil {
+			return err
+		}
+
+		schemaFileName := path.Join(dir, jsonSchemaFilename(name))
+
+		err = g.writeFile(b, schemaFileName)
+		if err != nil {
+			return err
+		}
+
+		g.debug.Printf("create %s at %+v", jsonSchemaFilename(name), schemaFileName)
+	}
+
+	return nil
+}
+
+// jsonSchemaFilename returns the name of the file of the JSON Schema of a definition, eg: model.Pet.schema.json.
+func jsonSchemaFilename(name string) string {
+	return nonFilenameCharacters.ReplaceAllString(name, "_") + ".schema.json"
+}
+
+// buildJSONSchemas converts the definitions roots, and the ones they refer to, into standalone JSON Schemas
+// by definition name. References to definitions become references to the files of their schemas, relative to
+// the $id of the schemas.
+func buildJSONSchemas(definitions spec.Definitions, roots []string, baseID string) (map[string]map[string]interface{}, error) {
+	schemas, err := convertJSONSchemas(definitions, roots, jsonSchemaFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, schema := range schemas {
+		schema["$schema"] = jsonSchemaDialect
+		schema["$id"] = baseID + jsonSchemaFilename(name)
+	}
+
+	return schemas, nil
+}
+
+// buildJSONSchemaBundle converts the definitions roots, and the ones they refer to, into a JSON Schema bundling them
+// in $defs. References to definitions become references to $defs.
+func buildJSONSchemaBundle(definitions spec.Definitions, roots []string, baseID string) (map[string]interface{}, error) {
+	schemas, err := convertJSONSchemas(definitions, roots, func(name string) string {
+		return "#/$defs/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make(map[string]interface{}, len(schemas))
+	for name, schema := range schemas {
+		defs[name] = schema
+	}
+
+	bundle := map[string]interface{}{
+		"$schema": jsonSchemaDialect,
+		"$defs":   defs,
+	}
+
+	if baseID != "" {
+		bundle["$id"] = baseID + "schema.json"
+	}
+
+	return bundle, nil
+}
+
+// convertJSONSchemas converts the definitions roots, and the ones they refer to, into JSON Schemas
+// by definition name, references to a definition are replaced by ref of its name.
+func convertJSONSchemas(definitions spec.Definitions, roots []string, ref func(name string) string) (map[string]map[string]interface{}, error) {
+	schemas := make(map[string]map[string]interface{})
+
+	queue := append([]string{}, roots...)
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if _, ok := schemas[name]; ok {
+			continue
+		}
+
+		definition, ok := definitions[name]
+		if !ok {
+			continue
+		}
+
+		b, err := json.Marshal(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		var schema map[string]interface{}
+
+		err = json.Unmarshal(b, &schema)
+		if err != nil {
+			return nil, err
+		}
+
+		var refs []string
+
+		convertJSONSchema(schema, ref, &refs)
+
+		schemas[name] = schema
+		queue = append(queue, refs...)
+	}
+
+	return schemas, nil
+}
+
+// convertJSONSchema converts a Swagger 2.0 schema to JSON Schema 2020-12 in place: references to definitions
+// are replaced by ref, x-nullable becomes a null type, example becomes examples, boolean exclusive bounds
+// become numeric ones, and extensions and the discriminator are left out.
+func convertJSONSchema(schema map[string]interface{}, ref func(name string) string, refs *[]string) {
+	if value, ok := schema["$ref"].(string); ok && strings.HasPrefix(value, "#/definitions/") {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(value, "#/definitions/"))
+		schema["$ref"] = ref(name)
+		*refs = append(*refs, name)
+	}
+
+	if example, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{example}
+		delete(schema, "example")
+	}
+
+	for _, bound := range []string{"maximum", "minimum"} {
+		exclusiveBound := "exclusive" + strings.ToUpper(bound[:1]) + bound[1:]
+
+		if exclusive, _ := schema[exclusiveBound].(bool); exclusive && schema[bound] != nil {
+			schema[exclusiveBound] = schema[bound]
+			delete(schema, bound)
+		} else {
+			delete(schema, exclusiveBound)
+		}
+	}
+
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+
+	delete(schema, "discriminator")
+
+	nullable, _ := schema["x-nullable"].(bool)
+
+	for key := range schema {
+		if strings.HasPrefix(key, "x-") {
+			delete(schema, key)
+		}
+	}
+
+	if nullable {
+		switch schemaType := schema["type"].(type) {
+		case string:
+			schema["type"] = []interface{}{schemaType, "null"}
+		case []interface{}:
+			schema["type"] = append(schemaType, "null")
+		default:
+			// a reference or a composition, the annotations stay with the nullable schema
+			nonNull := make(map[string]interface{})
+
+			for key, value := range schema {
+				switch key {
+				case "title", "description", "examples", "default", "readOnly":
+				default:
+					nonNull[key] = value
+					delete(schema, key)
+				}
+			}
+
+			schema["anyOf"] = []interface{}{nonNull, map[string]interface{}{"type": "null"}}
+		}
+	}
+
+	for _, key := range []string{"properties", "patternProperties"} {
+		if properties, ok := schema[key].(map[string]interface{}); ok {
+			for _, property := range properties {
+				if property, ok := property.(map[string]interface{}); ok {
+					convertJSONSchema(property, ref, refs)
+				}
+			}
+		}
+	}
+
+	for _, key := range []string{"additionalProperties", "items", "not"} {
+		if subSchema, ok := schema[key].(map[string]interface{}); ok {
+			convertJSONSchema(subSchema, ref, refs)
+		}
+	}
+
+	if items, ok := schema["items"].([]interface{}); ok {
+		schema["prefixItems"] = items
+		delete(schema, "items")
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		if subSchemas, ok := schema[key].([]interface{}); ok {
+			for _, subSchema := range subSchemas {
+				if subSchema, ok := subSchema.(map[string]interface{}); ok {
+					convertJSONSchema(subSchema, ref, refs)
+				}
+			}
+		}
+	}
+}
+
+func sortedSchemaNames(schemas map[string]map[string]interface{}) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
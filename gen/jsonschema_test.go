@@ -0,0 +1,122 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertJSONSchema(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "object",
+		"discriminator": "kind",
+		"x-order": 1,
+		"properties": {
+			"x-name": {"type": "string", "x-nullable": true, "example": "gopher"},
+			"age": {"type": "integer", "maximum": 150, "exclusiveMaximum": true, "minimum": 0, "exclusiveMinimum": false},
+			"owner": {"description": "owner", "x-nullable": true, "allOf": [{"$ref": "#/definitions/model.Owner"}]},
+			"tags": {"type": "array", "items": {"$ref": "#/definitions/model.Tag"}}
+		}
+	}`), &schema))
+
+	var refs []string
+
+	convertJSONSchema(schema, func(name string) string { return name + ".schema.json" }, &refs)
+
+	expected := `{
+		"type": "object",
+		"properties": {
+			"x-name": {"type": ["string", "null"], "examples": ["gopher"]},
+			"age": {"type": "integer", "exclusiveMaximum": 150, "minimum": 0},
+			"owner": {
+				"description": "owner",
+				"anyOf": [{"allOf": [{"$ref": "model.Owner.schema.json"}]}, {"type": "null"}]
+			},
+			"tags": {"type": "array", "items": {"$ref": "model.Tag.schema.json"}}
+		}
+	}`
+
+	b, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(b))
+	assert.ElementsMatch(t, []string{"model.Owner", "model.Tag"}, refs)
+}
+
+func TestBuildJSONSchemaBundle(t *testing.T) {
+	definitions := spec.Definitions{
+		"model.Pet":   *spec.RefSchema("#/definitions/model.Owner"),
+		"model.Owner": *spec.StringProperty(),
+		"model.Other": *spec.StringProperty(),
+	}
+
+	bundle, err := buildJSONSchemaBundle(definitions, []string{"model.Pet"}, "https://example.com/schemas/")
+	require.NoError(t, err)
+
+	b, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "https://example.com/schemas/schema.json",
+		"$defs": {
+			"model.Pet": {"$ref": "#/$defs/model.Owner"},
+			"model.Owner": {"type": "string"}
+		}
+	}`, string(b))
+}
+
+func TestGen_BuildJSONSchemas(t *testing.T) {
+	config := &Config{
+		SearchDir:       "../testdata/jsonschema",
+		MainAPIFile:     "./main.go",
+		OutputDir:       "../testdata/jsonschema/docs",
+		OutputTypes:     []string{"json", "jsonschema"},
+		JSONSchemaTypes: []string{"model.Config"},
+		JSONSchemaID:    "https://example.com/schemas/",
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	files, err := filepath.Glob(filepath.Join(config.OutputDir, "schemas", "*.schema.json"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, filepath.Base(file))
+	}
+
+	// the annotated and listed types, with the types they refer to.
+	assert.Equal(t, []string{
+		"model.Config.schema.json",
+		"model.Event.schema.json",
+		"model.Level.schema.json",
+		"model.Payload.schema.json",
+		"model.Rule.schema.json",
+	}, names)
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "schemas", "model.Config.schema.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "https://example.com/schemas/model.Config.schema.json",
+		"type": "object",
+		"properties": {
+			"rules": {"type": "array", "items": {"$ref": "model.Rule.schema.json"}}
+		}
+	}`, string(b))
+
+	// the swagger document only has the definitions operations use.
+	var swagger spec.Swagger
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &swagger))
+	assert.Len(t, swagger.Definitions, 2)
+	assert.NotContains(t, swagger.Definitions, "model.Event")
+}
@@ -0,0 +1,218 @@
+package gen
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-openapi/spec"
+)
+
+var nonAnchorCharacters = regexp.MustCompile(`[^\w\- ]+`)
+
+// referenceFuncs are the functions available to the markdown and html templates.
+var referenceFuncs = map[string]interface{}{
+	// anchor returns the anchor markdown renderers generate for a heading.
+	"anchor": func(heading string) string {
+		heading = nonAnchorCharacters.ReplaceAllString(strings.ToLower(heading), "")
+
+		return strings.ReplaceAll(strings.TrimSpace(heading), " ", "-")
+	},
+	// cell escapes a value for a markdown table cell.
+	"cell": func(value string) string {
+		value = strings.ReplaceAll(value, "|", `\|`)
+
+		return strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+	},
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+}
+
+func (g *Gen) writeMarkdownReference(config *Config, swagger *spec.Swagger) error {
+	text := markdownTemplate
+
+	if config.MarkdownTemplate != "" {
+		b, err := os.ReadFile(config.MarkdownTemplate)
+		if err != nil {
+			return err
+		}
+
+		text = string(b)
+	}
+
+	tpl, err := template.New("markdown").Funcs(referenceFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	err = tpl.Execute(&buf, buildReferenceDoc(swagger))
+	if err != nil {
+		return err
+	}
+
+	markdownFileName := path.Join(config.OutputDir, outputFilename(config, "api.md"))
+
+	err = g.writeFile(buf.Bytes(), markdownFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create api.md at %+v", markdownFileName)
+
+	return nil
+}
+
+func (g *Gen) writeHTMLReference(config *Config, swagger *spec.Swagger) error {
+	text := htmlTemplate
+
+	if config.HTMLTemplate != "" {
+		b, err := os.ReadFile(config.HTMLTemplate)
+		if err != nil {
+			return err
+		}
+
+		text = string(b)
+	}
+
+	tpl, err := htmltemplate.New("html").Funcs(referenceFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	err = tpl.Execute(&buf, buildReferenceDoc(swagger))
+	if err != nil {
+		return err
+	}
+
+	htmlFileName := path.Join(config.OutputDir, outputFilename(config, "api.html"))
+
+	err = g.writeFile(buf.Bytes(), htmlFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create api.html at %+v", htmlFileName)
+
+	return nil
+}
+
+var markdownTemplate = `# {{ .Title }}
+{{ with .Version }}
+Version: {{ . }}
+{{ end }}{{ with .Description }}
+{{ . }}
+{{ end }}
+Base URL: ` + "`{{ .BaseURL }}`" + `
+{{ range .Tags }}
+## {{ or .Name "Other" }}
+{{ with .Description }}
+{{ . }}
+{{ end }}{{ range .Operations }}
+### {{ or .Summary (print .Method " " .Path) }}
+
+` + "`{{ .Method }} {{ .Path }}`" + `{{ if .Deprecated }} **Deprecated**{{ end }}
+{{ with .Description }}
+{{ . }}
+{{ end }}{{ with .Parameters }}
+#### Parameters
+
+| Name | In | Type | Required | Description |
+| ---- | -- | ---- | -------- | ----------- |
+{{ range . }}| {{ .Name }} | {{ .In }} | {{ .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell .Description }}{{ with .Constraints }} ({{ cell . }}){{ end }}{{ with .Enum }}<br>Enum: {{ range $i, $e := . }}{{ if $i }}, {{ end }}` + "`{{ $e.Value }}`" + `{{ end }}{{ end }} |
+{{ end }}{{ end }}{{ with .Body }}
+#### Request body
+
+Type: ` + "`{{ .Type }}`" + `{{ with .Description }} - {{ . }}{{ end }}
+{{ template "schema" . }}{{ end }}{{ with .Responses }}
+#### Responses
+{{ range . }}
+##### {{ .Code }}{{ with .Description }} - {{ . }}{{ end }}
+{{ with .Schema }}
+Type: ` + "`{{ .Type }}`" + `
+{{ template "schema" . }}{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}
+{{- define "schema" }}{{ with .Fields }}
+| Field | Type | Required | Description |
+| ----- | ---- | -------- | ----------- |
+{{ range . }}| {{ .Name }} | {{ .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell .Description }}{{ with .Constraints }} ({{ cell . }}){{ end }} |
+{{ end }}{{ range . }}{{ if .Enum }}
+Values of ` + "`{{ .Name }}`" + `:
+
+| Value | Name | Description |
+| ----- | ---- | ----------- |
+{{ range .Enum }}| ` + "`{{ .Value }}`" + ` | {{ .Name }} | {{ cell .Description }} |
+{{ end }}{{ end }}{{ end }}{{ end }}{{ with .Example }}
+` + "```json" + `
+{{ . }}
+` + "```" + `
+{{ end }}{{ end }}`
+
+var htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; max-width: 960px; margin: auto; padding: 0 1em; color: #222; }
+table { border-collapse: collapse; margin: 1em 0; width: 100%; }
+th, td { border: 1px solid #ddd; padding: .4em .6em; text-align: left; vertical-align: top; }
+code, pre { background: #f5f5f5; border-radius: 3px; }
+pre { padding: 1em; overflow: auto; }
+.method { font-weight: bold; text-transform: uppercase; }
+.deprecated { color: #b00; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+{{ with .Version }}<p>Version: {{ . }}</p>{{ end }}
+{{ with .Description }}<p>{{ . }}</p>{{ end }}
+<p>Base URL: <code>{{ .BaseURL }}</code></p>
+<nav><ul>
+{{ range .Tags }}<li><a href="#{{ anchor (or .Name "Other") }}">{{ or .Name "Other" }}</a></li>
+{{ end }}</ul></nav>
+{{ range .Tags }}
+<section id="{{ anchor (or .Name "Other") }}">
+<h2>{{ or .Name "Other" }}</h2>
+{{ with .Description }}<p>{{ . }}</p>{{ end }}
+{{ range .Operations }}
+<article>
+<h3>{{ or .Summary (print .Method " " .Path) }}</h3>
+<p><code><span class="method">{{ .Method }}</span> {{ .Path }}</code>{{ if .Deprecated }} <span class="deprecated">Deprecated</span>{{ end }}</p>
+{{ with .Description }}<p>{{ . }}</p>{{ end }}
+{{ with .Parameters }}
+<h4>Parameters</h4>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{ range . }}<tr><td>{{ .Name }}</td><td>{{ .In }}</td><td>{{ .Type }}</td><td>{{ if .Required }}yes{{ else }}no{{ end }}</td><td>{{ .Description }}{{ with .Constraints }} ({{ . }}){{ end }}{{ with .Enum }}<br>Enum: {{ range $i, $e := . }}{{ if $i }}, {{ end }}<code>{{ $e.Value }}</code>{{ end }}{{ end }}</td></tr>
+{{ end }}</table>
+{{ end }}{{ with .Body }}
+<h4>Request body</h4>
+<p>Type: <code>{{ .Type }}</code>{{ with .Description }} - {{ . }}{{ end }}</p>
+{{ template "schema" . }}
+{{ end }}{{ with .Responses }}
+<h4>Responses</h4>
+{{ range . }}
+<h5>{{ .Code }}{{ with .Description }} - {{ . }}{{ end }}</h5>
+{{ with .Schema }}<p>Type: <code>{{ .Type }}</code></p>
+{{ template "schema" . }}{{ end }}
+{{ end }}{{ end }}
+</article>
+{{ end }}
+</section>
+{{ end }}
+</body>
+</html>
+{{- define "schema" }}{{ with .Fields }}
+<table>
+<tr><th>Field</th><th>Type</th><th>Required</th><th>Description</th></tr>
+{{ range . }}<tr><td>{{ .Name }}</td><td>{{ .Type }}</td><td>{{ if .Required }}yes{{ else }}no{{ end }}</td><td>{{ .Description }}{{ with .Constraints }} ({{ . }}){{ end }}{{ with .Enum }}<table>{{ range . }}<tr><td><code>{{ .Value }}</code></td><td>{{ .Name }}</td><td>{{ .Description }}</td></tr>{{ end }}</table>{{ end }}</td></tr>
+{{ end }}</table>
+{{ end }}{{ with .Example }}<pre><code>{{ . }}</code></pre>
+{{ end }}{{ end }}`
@@ -0,0 +1,80 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGen_writeMarkdownReference(t *testing.T) {
+	swagger := loadTestPetstore(t)
+
+	status := *spec.StringProperty()
+	status.Enum = []interface{}{"available", "sold"}
+	status.Extensions = spec.Extensions{
+		"x-enum-varnames": []string{"StatusAvailable", "StatusSold"},
+		"x-enum-comments": map[string]string{"StatusSold": "no longer available"},
+	}
+	swagger.Definitions["model.Status"] = status
+
+	pet := swagger.Definitions["model.Pet"]
+	pet.Properties["status"] = *spec.RefSchema("#/definitions/model.Status")
+	name := pet.Properties["name"]
+	pet.Properties["name"] = *name.WithMaxLength(32).WithDescription("name | nickname")
+	pet.Required = []string{"name"}
+	swagger.Definitions["model.Pet"] = pet
+
+	config := &Config{OutputDir: t.TempDir(), InstanceName: "swagger"}
+	require.NoError(t, New().writeMarkdownReference(config, swagger))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "api.md"))
+	require.NoError(t, err)
+
+	md := string(b)
+	assert.Contains(t, md, "# Petstore\n")
+	assert.Contains(t, md, "Base URL: `https://petstore.example.com/v1`\n")
+	assert.Contains(t, md, "## pet\n\nEverything about pets\n")
+	assert.Contains(t, md, "### Add a pet\n\n`POST /pets`\n")
+	assert.Contains(t, md, "### GET /pets/{id}\n")
+	assert.Contains(t, md, "| id | path | integer | yes |  |\n")
+	assert.Contains(t, md, "| name | string | yes | name \\| nickname (maxLength: 32) |\n")
+	assert.Contains(t, md, "| owner.pets | array of model.Pet | no |  |\n")
+	assert.Contains(t, md, "Values of `status`:\n")
+	assert.Contains(t, md, "| `sold` | StatusSold | no longer available |\n")
+	assert.Contains(t, md, "```json\n{\n  \"name\": \"doggie\",")
+	assert.Contains(t, md, "## Other\n\n### Health\n")
+
+	custom := filepath.Join(config.OutputDir, "custom.tmpl")
+	require.NoError(t, os.WriteFile(custom, []byte(`{{ range .Tags }}{{ anchor .Name }}:{{ len .Operations }} {{ end }}`), 0o600))
+
+	config.MarkdownTemplate = custom
+	require.NoError(t, New().writeMarkdownReference(config, swagger))
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "api.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "user:1 pet:2 :1 ", string(b))
+
+	config.MarkdownTemplate = filepath.Join(config.OutputDir, "missing.tmpl")
+	assert.Error(t, New().writeMarkdownReference(config, swagger))
+}
+
+func TestGen_writeHTMLReference(t *testing.T) {
+	swagger := loadTestPetstore(t)
+	swagger.Info.Description = "<b>pets</b>"
+
+	config := &Config{OutputDir: t.TempDir(), InstanceName: "swagger"}
+	require.NoError(t, New().writeHTMLReference(config, swagger))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "api.html"))
+	require.NoError(t, err)
+
+	html := string(b)
+	assert.Contains(t, html, "<title>Petstore</title>")
+	assert.Contains(t, html, "<p>&lt;b&gt;pets&lt;/b&gt;</p>")
+	assert.Contains(t, html, `<section id="pet">`)
+	assert.Contains(t, html, "<tr><td>owner.pets</td><td>array of model.Pet</td><td>no</td><td></td></tr>")
+}
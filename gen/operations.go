@@ -0,0 +1,311 @@
+package gen
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// operationMethods the order operations of a path are listed in by the generated clients and collections.
+var operationMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
+type pathOperation struct {
+	Method    string
+	Path      string
+	Operation *spec.Operation
+}
+
+// Name returns the summary of the operation, or its method and path if it has none.
+func (o pathOperation) Name() string {
+	if o.Operation.Summary != "" {
+		return o.Operation.Summary
+	}
+
+	return o.Method + " " + o.Path
+}
+
+// listOperations returns the operations of swagger sorted by path and method.
+func listOperations(swagger *spec.Swagger) []pathOperation {
+	if swagger.Paths == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(swagger.Paths.Paths))
+	for path := range swagger.Paths.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	var operations []pathOperation
+
+	for _, path := range paths {
+		item := swagger.Paths.Paths[path]
+
+		for _, method := range operationMethods {
+			var op *spec.Operation
+
+			switch method {
+			case http.MethodGet:
+				op = item.Get
+			case http.MethodPost:
+				op = item.Post
+			case http.MethodPut:
+				op = item.Put
+			case http.MethodPatch:
+				op = item.Patch
+			case http.MethodDelete:
+				op = item.Delete
+			case http.MethodHead:
+				op = item.Head
+			case http.MethodOptions:
+				op = item.Options
+			}
+
+			if op != nil {
+				operations = append(operations, pathOperation{Method: method, Path: path, Operation: op})
+			}
+		}
+	}
+
+	return operations
+}
+
+// groupOperationsByTag returns the tags in the order they are declared, then by name for the undeclared ones,
+// and the operations of each tag. An operation with several tags is listed under each of them,
+// untagged operations are listed under the empty tag, which comes last.
+func groupOperationsByTag(swagger *spec.Swagger) ([]string, map[string][]pathOperation) {
+	groups := make(map[string][]pathOperation)
+
+	for _, op := range listOperations(swagger) {
+		if len(op.Operation.Tags) == 0 {
+			groups[""] = append(groups[""], op)
+
+			continue
+		}
+
+		for _, tag := range op.Operation.Tags {
+			groups[tag] = append(groups[tag], op)
+		}
+	}
+
+	var tags []string
+
+	for _, tag := range swagger.Tags {
+		if _, ok := groups[tag.Name]; ok {
+			tags = append(tags, tag.Name)
+		}
+	}
+
+	var undeclared []string
+
+	for tag := range groups {
+		if tag != "" && !containsString(tags, tag) {
+			undeclared = append(undeclared, tag)
+		}
+	}
+
+	sort.Strings(undeclared)
+	tags = append(tags, undeclared...)
+
+	if _, ok := groups[""]; ok {
+		tags = append(tags, "")
+	}
+
+	return tags, groups
+}
+
+// operationSecurity returns the security requirements of op, or the global ones if op does not declare any.
+func operationSecurity(swagger *spec.Swagger, op *spec.Operation) []map[string][]string {
+	if op.Security != nil {
+		return op.Security
+	}
+
+	return swagger.Security
+}
+
+// baseURL returns the URL operations paths are relative to.
+func baseURL(swagger *spec.Swagger) string {
+	scheme := "http"
+	if len(swagger.Schemes) > 0 {
+		scheme = swagger.Schemes[0]
+	}
+
+	host := swagger.Host
+	if host == "" {
+		host = "localhost"
+	}
+
+	return scheme + "://" + host + strings.TrimSuffix(swagger.BasePath, "/")
+}
+
+// bodyParameter returns the body parameter of op, if any.
+func bodyParameter(op *spec.Operation) *spec.Parameter {
+	for i := range op.Parameters {
+		if op.Parameters[i].In == "body" {
+			return &op.Parameters[i]
+		}
+	}
+
+	return nil
+}
+
+// parameterExample returns an example value of param from its example, default or enum.
+func parameterExample(param *spec.Parameter) interface{} {
+	switch {
+	case param.Example != nil:
+		return param.Example
+	case param.Default != nil:
+		return param.Default
+	case len(param.Enum) > 0:
+		return param.Enum[0]
+	}
+
+	return nil
+}
+
+// schemaExample builds an example value of schema from the examples, defaults and enums of its properties.
+func schemaExample(swagger *spec.Swagger, schema *spec.Schema) interface{} {
+	return buildSchemaExample(swagger, schema, map[string]bool{})
+}
+
+func buildSchemaExample(swagger *spec.Swagger, schema *spec.Schema, visited map[string]bool) interface{} {
+	if schema == nil {
+		return nil
+	}
+
+	if ref := schema.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		if visited[name] {
+			return nil
+		}
+
+		definition, ok := swagger.Definitions[name]
+		if !ok {
+			return nil
+		}
+
+		visited[name] = true
+		defer delete(visited, name)
+
+		return buildSchemaExample(swagger, &definition, visited)
+	}
+
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]interface{})
+
+		for i := range schema.AllOf {
+			value := buildSchemaExample(swagger, &schema.AllOf[i], visited)
+			if object, ok := value.(map[string]interface{}); ok {
+				for key, v := range object {
+					merged[key] = v
+				}
+			} else if value != nil && len(schema.AllOf) == 1 {
+				return value
+			}
+		}
+
+		return merged
+	}
+
+	switch {
+	case schema.Type.Contains(swag.ARRAY):
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return []interface{}{}
+		}
+
+		item := buildSchemaExample(swagger, schema.Items.Schema, visited)
+		if item == nil {
+			return []interface{}{}
+		}
+
+		return []interface{}{item}
+	case schema.Type.Contains(swag.STRING):
+		switch schema.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		case "date":
+			return "2006-01-02"
+		}
+
+		return "string"
+	case schema.Type.Contains(swag.INTEGER), schema.Type.Contains(swag.NUMBER):
+		return 0
+	case schema.Type.Contains(swag.BOOLEAN):
+		return false
+	}
+
+	object := make(map[string]interface{}, len(schema.Properties))
+
+	for name, prop := range schema.Properties {
+		prop := prop
+		object[name] = buildSchemaExample(swagger, &prop, visited)
+	}
+
+	return object
+}
+
+var nonFilenameCharacters = regexp.MustCompile(`[^\w\-.]+`)
+
+// tagFilename returns a filename for the operations of tag, eg: the tag "pet store" gives "pet_store".
+func tagFilename(tag string) string {
+	if tag == "" {
+		return "default"
+	}
+
+	return nonFilenameCharacters.ReplaceAllString(tag, "_")
+}
+
+// tagFilenames returns the filenames of the tags by tag, suffixed with a number when the filename of a tag
+// collides with the one of a previous tag, ignoring case for case-insensitive file systems,
+// eg: the tags "pet store" and "Pet_Store" give "pet_store" and "Pet_Store_2".
+func tagFilenames(tags []string) map[string]string {
+	filenames := make(map[string]string, len(tags))
+	used := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		base := tagFilename(tag)
+		filename := base
+
+		for i := 2; used[strings.ToLower(filename)]; i++ {
+			filename = fmt.Sprintf("%s_%d", base, i)
+		}
+
+		used[strings.ToLower(filename)] = true
+		filenames[tag] = filename
+	}
+
+	return filenames
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,323 @@
+package gen
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Auth     *postmanAuth      `json:"auth,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is either a folder of items, or a request.
+type postmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []postmanItem   `json:"item,omitempty"`
+	Request     *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string            `json:"method"`
+	Header      []postmanVariable `json:"header"`
+	URL         postmanURL        `json:"url"`
+	Body        *postmanBody      `json:"body,omitempty"`
+	Auth        *postmanAuth      `json:"auth,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []postmanVariable `json:"query,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw,omitempty"`
+	URLEncoded []postmanVariable `json:"urlencoded,omitempty"`
+	FormData   []postmanVariable `json:"formdata,omitempty"`
+	Options    *postmanOptions   `json:"options,omitempty"`
+}
+
+type postmanOptions struct {
+	Raw postmanRawOptions `json:"raw"`
+}
+
+type postmanRawOptions struct {
+	Language string `json:"language"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Basic  []postmanVariable `json:"basic,omitempty"`
+	APIKey []postmanVariable `json:"apikey,omitempty"`
+	OAuth2 []postmanVariable `json:"oauth2,omitempty"`
+}
+
+// postmanVariable is used for variables, headers, query and form parameters, and auth attributes.
+type postmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// postmanGrantTypes maps swagger oauth2 flows to postman grant types.
+var postmanGrantTypes = map[string]string{
+	"accessCode":  "authorization_code",
+	"application": "client_credentials",
+	"password":    "password_credentials",
+	"implicit":    "implicit",
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)}`)
+
+func (g *Gen) writePostmanCollection(config *Config, swagger *spec.Swagger) error {
+	collection := buildPostmanCollection(swagger)
+
+	b, err := g.jsonIndent(collection)
+	if err != nil {
+		return err
+	}
+
+	postmanFileName := path.Join(config.OutputDir, outputFilename(config, "postman_collection.json"))
+
+	err = g.writeFile(b, postmanFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create postman_collection.json at %+v", postmanFileName)
+
+	return nil
+}
+
+// buildPostmanCollection converts swagger into a Postman collection with a folder per tag.
+func buildPostmanCollection(swagger *spec.Swagger) *postmanCollection {
+	collection := &postmanCollection{
+		Info:     postmanInfo{Schema: postmanSchema},
+		Variable: []postmanVariable{{Key: "baseUrl", Value: baseURL(swagger), Type: "string"}},
+	}
+
+	if swagger.Info != nil {
+		collection.Info.Name = swagger.Info.Title
+		collection.Info.Description = swagger.Info.Description
+		collection.Info.Version = swagger.Info.Version
+	}
+
+	if len(swagger.Security) > 0 {
+		collection.Auth = buildPostmanAuth(swagger, swagger.Security)
+	}
+
+	tagDescriptions := make(map[string]string)
+	for _, tag := range swagger.Tags {
+		tagDescriptions[tag.Name] = tag.Description
+	}
+
+	tags, groups := groupOperationsByTag(swagger)
+
+	for _, tag := range tags {
+		var items []postmanItem
+		for _, op := range groups[tag] {
+			items = append(items, buildPostmanItem(swagger, op))
+		}
+
+		if tag == "" {
+			collection.Item = append(collection.Item, items...)
+
+			continue
+		}
+
+		collection.Item = append(collection.Item, postmanItem{
+			Name:        tag,
+			Description: tagDescriptions[tag],
+			Item:        items,
+		})
+	}
+
+	return collection
+}
+
+func buildPostmanItem(swagger *spec.Swagger, op pathOperation) postmanItem {
+	request := &postmanRequest{
+		Method:      op.Method,
+		Header:      []postmanVariable{},
+		Description: op.Operation.Description,
+	}
+
+	rawPath := pathParamPattern.ReplaceAllString(op.Path, ":$1")
+
+	request.URL = postmanURL{
+		Host: []string{"{{baseUrl}}"},
+		Path: strings.Split(strings.TrimPrefix(rawPath, "/"), "/"),
+	}
+
+	var form []postmanVariable
+
+	isMultipart := containsString(op.Operation.Consumes, "multipart/form-data")
+
+	for _, param := range op.Operation.Parameters {
+		param := param
+
+		value := ""
+		if example := parameterExample(&param); example != nil {
+			value = formatValue(example)
+		}
+
+		variable := postmanVariable{Key: param.Name, Value: value, Description: param.Description}
+
+		switch param.In {
+		case "path":
+			request.URL.Variable = append(request.URL.Variable, variable)
+		case "query":
+			variable.Disabled = !param.Required
+			request.URL.Query = append(request.URL.Query, variable)
+		case "header":
+			request.Header = append(request.Header, variable)
+		case "formData":
+			if param.Type == "file" {
+				isMultipart = true
+				variable.Type = "file"
+			} else {
+				variable.Type = "text"
+			}
+
+			form = append(form, variable)
+		case "body":
+			example, _ := json.MarshalIndent(schemaExample(swagger, param.Schema), "", "  ")
+			request.Body = &postmanBody{
+				Mode:    "raw",
+				Raw:     string(example),
+				Options: &postmanOptions{Raw: postmanRawOptions{Language: "json"}},
+			}
+		}
+	}
+
+	switch {
+	case len(form) > 0 && isMultipart:
+		request.Body = &postmanBody{Mode: "formdata", FormData: form}
+	case len(form) > 0:
+		request.Body = &postmanBody{Mode: "urlencoded", URLEncoded: form}
+	}
+
+	if request.Body != nil && request.Body.Mode == "raw" {
+		request.Header = append(request.Header, postmanVariable{Key: "Content-Type", Value: "application/json"})
+	}
+
+	request.URL.Raw = "{{baseUrl}}" + rawPath
+
+	var query []string
+
+	for _, variable := range request.URL.Query {
+		if !variable.Disabled {
+			query = append(query, variable.Key+"="+variable.Value)
+		}
+	}
+
+	if len(query) > 0 {
+		request.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if op.Operation.Security != nil {
+		request.Auth = buildPostmanAuth(swagger, op.Operation.Security)
+	}
+
+	return postmanItem{Name: op.Name(), Request: request}
+}
+
+// buildPostmanAuth converts the first security requirement into a Postman auth, variables are
+// used for credentials.
+func buildPostmanAuth(swagger *spec.Swagger, security []map[string][]string) *postmanAuth {
+	if len(security) == 0 || len(security[0]) == 0 {
+		return &postmanAuth{Type: "noauth"}
+	}
+
+	names := make([]string, 0, len(security[0]))
+	for name := range security[0] {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		scheme, ok := swagger.SecurityDefinitions[name]
+		if !ok {
+			continue
+		}
+
+		switch scheme.Type {
+		case "basic":
+			return &postmanAuth{
+				Type: "basic",
+				Basic: []postmanVariable{
+					{Key: "username", Value: "{{username}}", Type: "string"},
+					{Key: "password", Value: "{{password}}", Type: "string"},
+				},
+			}
+		case "apiKey":
+			return &postmanAuth{
+				Type: "apikey",
+				APIKey: []postmanVariable{
+					{Key: "key", Value: scheme.Name, Type: "string"},
+					{Key: "value", Value: "{{" + name + "}}", Type: "string"},
+					{Key: "in", Value: scheme.In, Type: "string"},
+				},
+			}
+		case "oauth2":
+			auth := &postmanAuth{
+				Type: "oauth2",
+				OAuth2: []postmanVariable{
+					{Key: "grant_type", Value: postmanGrantTypes[scheme.Flow], Type: "string"},
+				},
+			}
+
+			if scheme.AuthorizationURL != "" {
+				auth.OAuth2 = append(auth.OAuth2, postmanVariable{Key: "authUrl", Value: scheme.AuthorizationURL, Type: "string"})
+			}
+
+			if scheme.TokenURL != "" {
+				auth.OAuth2 = append(auth.OAuth2, postmanVariable{Key: "accessTokenUrl", Value: scheme.TokenURL, Type: "string"})
+			}
+
+			if scopes := security[0][name]; len(scopes) > 0 {
+				auth.OAuth2 = append(auth.OAuth2, postmanVariable{Key: "scope", Value: strings.Join(scopes, " "), Type: "string"})
+			}
+
+			return auth
+		}
+	}
+
+	return nil
+}
+
+// formatValue formats an example value of a parameter.
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	b, _ := json.Marshal(value)
+
+	return string(b)
+}
@@ -0,0 +1,153 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPetstore = `{
+    "swagger": "2.0",
+    "info": {"title": "Petstore", "description": "pets", "version": "1.0"},
+    "host": "petstore.example.com",
+    "basePath": "/v1",
+    "schemes": ["https"],
+    "paths": {
+        "/pets": {
+            "post": {
+                "tags": ["pet"],
+                "summary": "Add a pet",
+                "operationId": "addPet",
+                "parameters": [
+                    {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.Pet"}},
+                    {"name": "X-Request-ID", "in": "header", "type": "string"}
+                ],
+                "responses": {"201": {"description": "Created"}}
+            }
+        },
+        "/pets/{id}": {
+            "get": {
+                "tags": ["pet"],
+                "operationId": "getPet",
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer", "example": 7},
+                    {"name": "verbose", "in": "query", "type": "boolean"}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "/health": {
+            "get": {
+                "summary": "Health",
+                "security": [],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "/login": {
+            "post": {
+                "tags": ["user"],
+                "summary": "Login",
+                "security": [{"Basic": []}],
+                "parameters": [
+                    {"name": "name", "in": "formData", "type": "string", "default": "admin"}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        }
+    },
+    "definitions": {
+        "model.Pet": {
+            "type": "object",
+            "properties": {
+                "name": {"type": "string", "example": "doggie"},
+                "tags": {"type": "array", "items": {"type": "string"}},
+                "owner": {"$ref": "#/definitions/model.Owner"}
+            }
+        },
+        "model.Owner": {
+            "type": "object",
+            "properties": {
+                "pets": {"type": "array", "items": {"$ref": "#/definitions/model.Pet"}}
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKey": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+        "Basic": {"type": "basic"}
+    },
+    "security": [{"ApiKey": []}],
+    "tags": [{"name": "user"}, {"name": "pet", "description": "Everything about pets"}]
+}`
+
+func loadTestPetstore(t *testing.T) *spec.Swagger {
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(testPetstore), &swagger))
+
+	return &swagger
+}
+
+func TestBuildPostmanCollection(t *testing.T) {
+	collection := buildPostmanCollection(loadTestPetstore(t))
+
+	assert.Equal(t, "Petstore", collection.Info.Name)
+	assert.Equal(t, postmanSchema, collection.Info.Schema)
+	assert.Equal(t, []postmanVariable{{Key: "baseUrl", Value: "https://petstore.example.com/v1", Type: "string"}}, collection.Variable)
+	assert.Equal(t, "apikey", collection.Auth.Type)
+	assert.Contains(t, collection.Auth.APIKey, postmanVariable{Key: "key", Value: "X-API-Key", Type: "string"})
+
+	// folders in order of declaration, untagged operations last at the root.
+	require.Len(t, collection.Item, 3)
+	assert.Equal(t, "user", collection.Item[0].Name)
+	assert.Equal(t, "pet", collection.Item[1].Name)
+	assert.Equal(t, "Everything about pets", collection.Item[1].Description)
+	assert.Equal(t, "Health", collection.Item[2].Name)
+	assert.Equal(t, &postmanAuth{Type: "noauth"}, collection.Item[2].Request.Auth)
+
+	login := collection.Item[0].Item[0].Request
+	assert.Equal(t, "basic", login.Auth.Type)
+	assert.Equal(t, &postmanBody{Mode: "urlencoded", URLEncoded: []postmanVariable{{Key: "name", Value: "admin", Type: "text"}}}, login.Body)
+
+	addPet := collection.Item[1].Item[0]
+	assert.Equal(t, "Add a pet", addPet.Name)
+	assert.Equal(t, "{{baseUrl}}/pets", addPet.Request.URL.Raw)
+	assert.Equal(t, "raw", addPet.Request.Body.Mode)
+	assert.JSONEq(t, `{"name": "doggie", "tags": ["string"], "owner": {"pets": []}}`, addPet.Request.Body.Raw)
+	assert.Contains(t, addPet.Request.Header, postmanVariable{Key: "Content-Type", Value: "application/json"})
+	assert.Contains(t, addPet.Request.Header, postmanVariable{Key: "X-Request-ID"})
+
+	getPet := collection.Item[1].Item[1]
+	assert.Equal(t, "GET /pets/{id}", getPet.Name)
+	assert.Equal(t, "{{baseUrl}}/pets/:id", getPet.Request.URL.Raw)
+	assert.Equal(t, []string{"pets", ":id"}, getPet.Request.URL.Path)
+	assert.Equal(t, []postmanVariable{{Key: "id", Value: "7"}}, getPet.Request.URL.Variable)
+	assert.Equal(t, []postmanVariable{{Key: "verbose", Disabled: true}}, getPet.Request.URL.Query)
+}
+
+func TestGen_BuildPostmanAndHTTP(t *testing.T) {
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/simple/docs",
+		OutputTypes: []string{"postman", "http"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "postman_collection.json"))
+	require.NoError(t, err)
+
+	var collection postmanCollection
+	require.NoError(t, json.Unmarshal(b, &collection))
+	assert.Equal(t, "Swagger Example API", collection.Info.Name)
+	assert.NotEmpty(t, collection.Item)
+
+	files, err := filepath.Glob(filepath.Join(config.OutputDir, "*.http"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, files)
+}
@@ -0,0 +1,464 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// ReferenceDoc is the data the markdown and html templates are executed with.
+type ReferenceDoc struct {
+	Title       string
+	Description string
+	Version     string
+	BaseURL     string
+	Tags        []ReferenceTag
+}
+
+// ReferenceTag groups the operations of a tag, Name is empty for untagged operations.
+type ReferenceTag struct {
+	Name        string
+	Description string
+	Operations  []ReferenceOperation
+}
+
+// ReferenceOperation describes an operation.
+type ReferenceOperation struct {
+	Method      string
+	Path        string
+	ID          string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Consumes    []string
+	Produces    []string
+	Parameters  []ReferenceParameter
+	Body        *ReferenceSchema
+	Responses   []ReferenceResponse
+}
+
+// ReferenceParameter describes a path, query, header or form parameter.
+type ReferenceParameter struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Constraints string
+	Enum        []ReferenceEnum
+}
+
+// ReferenceResponse describes the response of a status code.
+type ReferenceResponse struct {
+	Code        string
+	Description string
+	Schema      *ReferenceSchema
+}
+
+// ReferenceSchema describes a request or response body, with its fields expanded.
+type ReferenceSchema struct {
+	Type        string
+	Description string
+	Fields      []ReferenceField
+	Example     string
+}
+
+// ReferenceField describes a field of a body, nested fields are named by their path, eg: owner.name or tags[].
+type ReferenceField struct {
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	Constraints string
+	Enum        []ReferenceEnum
+}
+
+// ReferenceEnum describes a value of an enum, named and described by x-enum-varnames and x-enum-descriptions.
+type ReferenceEnum struct {
+	Value       string
+	Name        string
+	Description string
+}
+
+// maxReferenceDepth limits the expansion of nested fields.
+const maxReferenceDepth = 8
+
+// buildReferenceDoc converts swagger into the data of the reference templates.
+func buildReferenceDoc(swagger *spec.Swagger) *ReferenceDoc {
+	doc := &ReferenceDoc{BaseURL: baseURL(swagger)}
+
+	if swagger.Info != nil {
+		doc.Title = swagger.Info.Title
+		doc.Description = swagger.Info.Description
+		doc.Version = swagger.Info.Version
+	}
+
+	tagDescriptions := make(map[string]string)
+	for _, tag := range swagger.Tags {
+		tagDescriptions[tag.Name] = tag.Description
+	}
+
+	tags, groups := groupOperationsByTag(swagger)
+
+	for _, tag := range tags {
+		referenceTag := ReferenceTag{Name: tag, Description: tagDescriptions[tag]}
+
+		for _, op := range groups[tag] {
+			referenceTag.Operations = append(referenceTag.Operations, buildReferenceOperation(swagger, op))
+		}
+
+		doc.Tags = append(doc.Tags, referenceTag)
+	}
+
+	return doc
+}
+
+func buildReferenceOperation(swagger *spec.Swagger, op pathOperation) ReferenceOperation {
+	operation := ReferenceOperation{
+		Method:      op.Method,
+		Path:        op.Path,
+		ID:          op.Operation.ID,
+		Summary:     op.Operation.Summary,
+		Description: op.Operation.Description,
+		Deprecated:  op.Operation.Deprecated,
+		Consumes:    op.Operation.Consumes,
+		Produces:    op.Operation.Produces,
+	}
+
+	for _, param := range op.Operation.Parameters {
+		if param.In == "body" {
+			operation.Body = buildReferenceSchema(swagger, param.Schema)
+			if operation.Body != nil && operation.Body.Description == "" {
+				operation.Body.Description = param.Description
+			}
+
+			continue
+		}
+
+		typeName := param.Type
+		if param.Type == swag.ARRAY && param.Items != nil {
+			typeName = "array of " + param.Items.Type
+		}
+
+		if param.Format != "" {
+			typeName += "(" + param.Format + ")"
+		}
+
+		operation.Parameters = append(operation.Parameters, ReferenceParameter{
+			Name:        param.Name,
+			In:          param.In,
+			Type:        typeName,
+			Required:    param.Required,
+			Description: param.Description,
+			Constraints: parameterConstraints(&param),
+			Enum:        buildReferenceEnum(param.Enum, param.Extensions),
+		})
+	}
+
+	if op.Operation.Responses != nil {
+		codes := sortedResponseCodes(op.Operation.Responses)
+		for _, code := range codes {
+			response := op.Operation.Responses.StatusCodeResponses[code]
+			operation.Responses = append(operation.Responses, ReferenceResponse{
+				Code:        fmt.Sprint(code),
+				Description: response.Description,
+				Schema:      buildReferenceSchema(swagger, response.Schema),
+			})
+		}
+
+		if response := op.Operation.Responses.Default; response != nil {
+			operation.Responses = append(operation.Responses, ReferenceResponse{
+				Code:        "default",
+				Description: response.Description,
+				Schema:      buildReferenceSchema(swagger, response.Schema),
+			})
+		}
+	}
+
+	return operation
+}
+
+func buildReferenceSchema(swagger *spec.Swagger, schema *spec.Schema) *ReferenceSchema {
+	if schema == nil {
+		return nil
+	}
+
+	reference := &ReferenceSchema{
+		Type:        schemaTypeName(schema),
+		Description: schema.Description,
+	}
+
+	if example := schemaExample(swagger, schema); example != nil {
+		b, err := json.MarshalIndent(example, "", "  ")
+		if err == nil {
+			reference.Example = string(b)
+		}
+	}
+
+	reference.Fields = expandFields(swagger, schema, "", map[string]bool{}, 0)
+
+	return reference
+}
+
+// expandFields returns the fields of schema and of its nested objects, named by their path from prefix.
+func expandFields(swagger *spec.Swagger, schema *spec.Schema, prefix string, visited map[string]bool, depth int) []ReferenceField {
+	if depth > maxReferenceDepth {
+		return nil
+	}
+
+	schema, name := resolveSchema(swagger, schema)
+	if name != "" {
+		if visited[name] {
+			return nil
+		}
+
+		visited[name] = true
+		defer delete(visited, name)
+	}
+
+	if schema.Type.Contains(swag.ARRAY) && schema.Items != nil && schema.Items.Schema != nil {
+		return expandFields(swagger, schema.Items.Schema, prefix+"[]", visited, depth+1)
+	}
+
+	var fields []ReferenceField
+
+	for _, allOf := range schema.AllOf {
+		allOf := allOf
+		fields = append(fields, expandFields(swagger, &allOf, prefix, visited, depth+1)...)
+	}
+
+	for _, propName := range sortedProperties(schema) {
+		prop := schema.Properties[propName]
+
+		fieldName := propName
+		if prefix != "" {
+			fieldName = prefix + "." + propName
+		}
+
+		resolved, _ := resolveSchema(swagger, &prop)
+
+		description := prop.Description
+		if description == "" {
+			description = resolved.Description
+		}
+
+		// the constraints of a field referencing a definition are set on its allOf wrapper.
+		constraints := schemaConstraints(&prop)
+		if constraints == "" {
+			constraints = schemaConstraints(resolved)
+		}
+
+		enum := buildReferenceEnum(prop.Enum, prop.Extensions)
+		if enum == nil {
+			enum = buildReferenceEnum(resolved.Enum, resolved.Extensions)
+		}
+
+		fields = append(fields, ReferenceField{
+			Name:        fieldName,
+			Type:        schemaTypeName(&prop),
+			Required:    containsString(schema.Required, propName),
+			Description: description,
+			Constraints: constraints,
+			Enum:        enum,
+		})
+
+		fields = append(fields, expandFields(swagger, &prop, fieldName, visited, depth+1)...)
+	}
+
+	return fields
+}
+
+// resolveSchema follows the $ref of schema, or of its single allOf, and returns the definition and its name.
+func resolveSchema(swagger *spec.Swagger, schema *spec.Schema) (*spec.Schema, string) {
+	if schema.Ref.String() == "" && len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return resolveSchema(swagger, &schema.AllOf[0])
+	}
+
+	name := strings.TrimPrefix(schema.Ref.String(), "#/definitions/")
+	if name == "" {
+		return schema, ""
+	}
+
+	definition, ok := swagger.Definitions[name]
+	if !ok {
+		return schema, ""
+	}
+
+	return &definition, name
+}
+
+// schemaTypeName returns a readable type of schema, eg: string(date-time), array of model.Pet.
+func schemaTypeName(schema *spec.Schema) string {
+	if ref := schema.Ref.String(); ref != "" {
+		return strings.TrimPrefix(ref, "#/definitions/")
+	}
+
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return schemaTypeName(&schema.AllOf[0])
+	}
+
+	if len(schema.Type) == 0 {
+		return swag.OBJECT
+	}
+
+	typeName := schema.Type[0]
+
+	switch typeName {
+	case swag.ARRAY:
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "array of " + schemaTypeName(schema.Items.Schema)
+		}
+	case swag.OBJECT:
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map of " + schemaTypeName(schema.AdditionalProperties.Schema)
+		}
+	}
+
+	if schema.Format != "" {
+		typeName += "(" + schema.Format + ")"
+	}
+
+	return typeName
+}
+
+func schemaConstraints(schema *spec.Schema) string {
+	var constraints []string
+
+	if schema.Minimum != nil {
+		constraints = append(constraints, fmt.Sprintf("min: %v", *schema.Minimum))
+	}
+
+	if schema.Maximum != nil {
+		constraints = append(constraints, fmt.Sprintf("max: %v", *schema.Maximum))
+	}
+
+	if schema.MinLength != nil {
+		constraints = append(constraints, fmt.Sprintf("minLength: %d", *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil {
+		constraints = append(constraints, fmt.Sprintf("maxLength: %d", *schema.MaxLength))
+	}
+
+	if schema.MinItems != nil {
+		constraints = append(constraints, fmt.Sprintf("minItems: %d", *schema.MinItems))
+	}
+
+	if schema.MaxItems != nil {
+		constraints = append(constraints, fmt.Sprintf("maxItems: %d", *schema.MaxItems))
+	}
+
+	if schema.Pattern != "" {
+		constraints = append(constraints, "pattern: "+schema.Pattern)
+	}
+
+	if schema.Default != nil {
+		constraints = append(constraints, "default: "+formatValue(schema.Default))
+	}
+
+	if schema.ReadOnly {
+		constraints = append(constraints, "read only")
+	}
+
+	return strings.Join(constraints, ", ")
+}
+
+func parameterConstraints(param *spec.Parameter) string {
+	return schemaConstraints(&spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Minimum:   param.Minimum,
+			Maximum:   param.Maximum,
+			MinLength: param.MinLength,
+			MaxLength: param.MaxLength,
+			MinItems:  param.MinItems,
+			MaxItems:  param.MaxItems,
+			Pattern:   param.Pattern,
+			Default:   param.Default,
+		},
+	})
+}
+
+func buildReferenceEnum(values []interface{}, extensions spec.Extensions) []ReferenceEnum {
+	if len(values) == 0 {
+		return nil
+	}
+
+	names := extensionStrings(extensions["x-enum-varnames"])
+	descriptions := extensionStrings(extensions["x-enum-descriptions"])
+
+	// x-enum-comments is keyed by name, x-enum-descriptions skips the values without comment.
+	var comments map[string]string
+
+	switch value := extensions["x-enum-comments"].(type) {
+	case map[string]string:
+		comments = value
+	case map[string]interface{}:
+		comments = make(map[string]string, len(value))
+		for name, comment := range value {
+			comments[name] = fmt.Sprint(comment)
+		}
+	}
+
+	enum := make([]ReferenceEnum, 0, len(values))
+
+	for i, value := range values {
+		item := ReferenceEnum{Value: formatValue(value)}
+
+		if i < len(names) {
+			item.Name = names[i]
+		}
+
+		switch {
+		case comments != nil:
+			item.Description = comments[item.Name]
+		case len(descriptions) == len(values):
+			item.Description = descriptions[i]
+		}
+
+		enum = append(enum, item)
+	}
+
+	return enum
+}
+
+func extensionStrings(value interface{}) []string {
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []interface{}:
+		strs := make([]string, 0, len(values))
+		for _, v := range values {
+			strs = append(strs, fmt.Sprint(v))
+		}
+
+		return strs
+	}
+
+	return nil
+}
+
+func sortedProperties(schema *spec.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func sortedResponseCodes(responses *spec.Responses) []int {
+	codes := make([]int, 0, len(responses.StatusCodeResponses))
+	for code := range responses.StatusCodeResponses {
+		codes = append(codes, code)
+	}
+
+	sort.Ints(codes)
+
+	return codes
+}
@@ -0,0 +1,419 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SplitByPackage writes the definitions of each package into a file of their own.
+	SplitByPackage = "package"
+
+	// SplitByType writes each definition into a file of its own.
+	SplitByType = "type"
+)
+
+// BundleConfig presents Bundle configurations.
+type BundleConfig struct {
+	// Input the root document of a swagger document written with split definitions
+	Input string
+
+	// Output the bundled document, written as json or yaml by its extension, the input is replaced if empty
+	Output string
+}
+
+// writeSplitSwagger writes swagger as filename, with its definitions in separate files of the definitions
+// directory, encoded by encode.
+func (g *Gen) writeSplitSwagger(config *Config, swagger *spec.Swagger, filename string, encode func(interface{}) ([]byte, error)) error {
+	b, err := g.json(swagger)
+	if err != nil {
+		return err
+	}
+
+	var doc map[string]interface{}
+
+	err = json.Unmarshal(b, &doc)
+	if err != nil {
+		return err
+	}
+
+	definitionsConfig := *config
+	if config.definitionsOf != "" {
+		definitionsConfig.InstanceName = config.definitionsOf
+	}
+
+	dirName := outputFilename(&definitionsConfig, "definitions")
+	ext := path.Ext(filename)
+
+	files, err := splitDefinitions(doc, config.SplitDefinitions, dirName, ext)
+	if err != nil {
+		return err
+	}
+
+	// the definitions are the ones written by the document of config.definitionsOf
+	if config.definitionsOf == "" {
+		err = g.writeDefinitionFiles(path.Join(config.OutputDir, dirName), files, ext, encode)
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err = encode(doc)
+	if err != nil {
+		return err
+	}
+
+	docFileName := path.Join(config.OutputDir, outputFilename(config, filename))
+
+	err = g.writeFile(b, docFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create %s at %+v", filename, docFileName)
+
+	return nil
+}
+
+// writeDefinitionFiles writes the definition files to dir, removing the ones of definitions which don't exist anymore.
+func (g *Gen) writeDefinitionFiles(dir string, files map[string]interface{}, ext string, encode func(interface{}) ([]byte, error)) error {
+	err := g.mkdirAll(dir)
+	if err != nil {
+		return err
+	}
+
+	// files of definitions which don't exist anymore
+	stale, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range stale {
+		if _, ok := files[filepath.Base(file)]; !ok {
+			err = g.removeFile(file)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, err := encode(files[name])
+		if err != nil {
+			return err
+		}
+
+		err = g.writeFile(b, path.Join(dir, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	g.debug.Printf("create %d definition files at %+v", len(names), dir)
+
+	return nil
+}
+
+// splitDefinitions moves the definitions of doc, a swagger document decoded from JSON, into files of dir by
+// package or by type, and replaces the references to them by references to the files, relative to the document.
+// It returns the content of the files by file name: a definition by type, or the definitions by name by package.
+func splitDefinitions(doc map[string]interface{}, mode, dir, ext string) (map[string]interface{}, error) {
+	if mode != SplitByPackage && mode != SplitByType {
+		return nil, fmt.Errorf("not supported %s split mode, use %s or %s", mode, SplitByPackage, SplitByType)
+	}
+
+	definitions, _ := doc["definitions"].(map[string]interface{})
+	delete(doc, "definitions")
+
+	// target returns the file and the fragment of the definition name
+	target := func(name string) (string, string) {
+		if mode == SplitByType {
+			return definitionFilename(name) + ext, ""
+		}
+
+		pkg := "default"
+		if separator := strings.Index(name, "."); separator != -1 {
+			pkg = name[:separator]
+		}
+
+		return definitionFilename(pkg) + ext, "#/" + escapeJSONPointer(name)
+	}
+
+	files := make(map[string]interface{})
+
+	for name, definition := range definitions {
+		file, _ := target(name)
+
+		// references of the definition are relative to its file
+		rewriteDefinitionRefs(definition, func(ref string) string {
+			refFile, fragment := target(ref)
+			if refFile == file && fragment != "" {
+				return fragment
+			}
+
+			return refFile + fragment
+		})
+
+		if mode == SplitByType {
+			if _, ok := files[file]; ok {
+				return nil, fmt.Errorf("definitions of the same file name %s, cannot split them by type", file)
+			}
+
+			files[file] = definition
+
+			continue
+		}
+
+		content, ok := files[file].(map[string]interface{})
+		if !ok {
+			content = make(map[string]interface{})
+			files[file] = content
+		}
+
+		content[name] = definition
+	}
+
+	rewriteDefinitionRefs(doc, func(ref string) string {
+		refFile, fragment := target(ref)
+
+		return dir + "/" + refFile + fragment
+	})
+
+	return files, nil
+}
+
+// rewriteDefinitionRefs replaces the references to definitions in node by the ones rewrite returns for their name.
+func rewriteDefinitionRefs(node interface{}, rewrite func(name string) string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" && strings.HasPrefix(ref, "#/definitions/") {
+				value[key] = rewrite(unescapeJSONPointer(strings.TrimPrefix(ref, "#/definitions/")))
+
+				continue
+			}
+
+			rewriteDefinitionRefs(child, rewrite)
+		}
+	case []interface{}:
+		for _, child := range value {
+			rewriteDefinitionRefs(child, rewrite)
+		}
+	}
+}
+
+// definitionFilename returns a file name for a definition or a package, without extension.
+func definitionFilename(name string) string {
+	return nonFilenameCharacters.ReplaceAllString(name, "_")
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func unescapeJSONPointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// Bundle inlines the definitions a swagger document written with split definitions refers to back into
+// its definitions, and writes it as a single document.
+func (g *Gen) Bundle(config *BundleConfig) error {
+	doc, err := bundleDocument(config.Input)
+	if err != nil {
+		return err
+	}
+
+	output := config.Output
+	if output == "" {
+		output = config.Input
+	}
+
+	encode := g.jsonIndent
+	if ext := filepath.Ext(output); ext == ".yaml" || ext == ".yml" {
+		encode = g.yamlIndent
+	}
+
+	b, err := encode(doc)
+	if err != nil {
+		return err
+	}
+
+	err = g.writeFile(b, output)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create %s at %+v", filepath.Base(output), output)
+
+	return nil
+}
+
+// bundleDocument reads the document rootFile and replaces its references to other files by references
+// to definitions, named by the fragment of the reference, or by the file name when it has none.
+func bundleDocument(rootFile string) (map[string]interface{}, error) {
+	files := make(map[string]interface{})
+
+	load := func(file string) (interface{}, error) {
+		if doc, ok := files[file]; ok {
+			return doc, nil
+		}
+
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if ext := filepath.Ext(file); ext == ".yaml" || ext == ".yml" {
+			b, err = yaml.YAMLToJSON(b)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+			}
+		}
+
+		var doc interface{}
+
+		err = json.Unmarshal(b, &doc)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+
+		files[file] = doc
+
+		return doc, nil
+	}
+
+	root, err := load(rootFile)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a swagger document", rootFile)
+	}
+
+	definitions, _ := doc["definitions"].(map[string]interface{})
+	if definitions == nil {
+		definitions = make(map[string]interface{})
+	}
+
+	// inlined the definitions already inlined, by file and fragment
+	inlined := make(map[string]string)
+
+	var walk func(node interface{}, file string) error
+
+	walk = func(node interface{}, file string) error {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			for key, child := range value {
+				ref, ok := child.(string)
+				if !ok || key != "$ref" || (file == rootFile && strings.HasPrefix(ref, "#")) {
+					if err := walk(child, file); err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				refFile, fragment := file, ref
+				if separator := strings.Index(ref, "#"); separator != -1 {
+					refFile, fragment = ref[:separator], ref[separator+1:]
+				} else {
+					refFile, fragment = ref, ""
+				}
+
+				if refFile == "" {
+					refFile = file
+				} else {
+					refFile = filepath.Join(filepath.Dir(file), filepath.FromSlash(refFile))
+				}
+
+				id := refFile + "#" + fragment
+
+				name, ok := inlined[id]
+				if !ok {
+					name = strings.TrimSuffix(filepath.Base(refFile), filepath.Ext(refFile))
+					if tokens := strings.Split(fragment, "/"); fragment != "" {
+						name = unescapeJSONPointer(tokens[len(tokens)-1])
+					}
+
+					refDoc, err := load(refFile)
+					if err != nil {
+						return err
+					}
+
+					definition, err := resolveJSONPointer(refDoc, fragment)
+					if err != nil {
+						return fmt.Errorf("cannot resolve %s in %s: %w", ref, file, err)
+					}
+
+					inlined[id] = name
+					definitions[name] = definition
+
+					if err = walk(definition, refFile); err != nil {
+						return err
+					}
+				}
+
+				value[key] = "#/definitions/" + escapeJSONPointer(name)
+			}
+		case []interface{}:
+			for _, child := range value {
+				if err := walk(child, file); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	err = walk(doc, rootFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(definitions) > 0 {
+		doc["definitions"] = definitions
+	}
+
+	return doc, nil
+}
+
+// resolveJSONPointer returns the value of doc the JSON pointer refers to.
+func resolveJSONPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+
+	node := doc
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s not found", pointer)
+		}
+
+		node, ok = object[unescapeJSONPointer(token)]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", pointer)
+		}
+	}
+
+	return node, nil
+}
@@ -0,0 +1,157 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDefinitions(t *testing.T) {
+	const src = `{
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/model.Pet"}}}}}
+		},
+		"definitions": {
+			"model.Pet": {
+				"type": "object",
+				"properties": {
+					"tag": {"$ref": "#/definitions/model.Tag"},
+					"owner": {"$ref": "#/definitions/user.Owner"}
+				}
+			},
+			"model.Tag": {"type": "string"},
+			"user.Owner": {"type": "object"}
+		}
+	}`
+
+	t.Run("package", func(t *testing.T) {
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(src), &doc))
+
+		files, err := splitDefinitions(doc, SplitByPackage, "definitions", ".json")
+		require.NoError(t, err)
+
+		b, err := json.Marshal(doc)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"paths": {
+				"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "definitions/model.json#/model.Pet"}}}}}
+			}
+		}`, string(b))
+
+		b, err = json.Marshal(files)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"model.json": {
+				"model.Pet": {
+					"type": "object",
+					"properties": {
+						"tag": {"$ref": "#/model.Tag"},
+						"owner": {"$ref": "user.json#/user.Owner"}
+					}
+				},
+				"model.Tag": {"type": "string"}
+			},
+			"user.json": {
+				"user.Owner": {"type": "object"}
+			}
+		}`, string(b))
+	})
+
+	t.Run("type", func(t *testing.T) {
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(src), &doc))
+
+		files, err := splitDefinitions(doc, SplitByType, "definitions", ".yaml")
+		require.NoError(t, err)
+
+		b, err := json.Marshal(doc)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"paths": {
+				"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "definitions/model.Pet.yaml"}}}}}
+			}
+		}`, string(b))
+
+		b, err = json.Marshal(files)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"model.Pet.yaml": {
+				"type": "object",
+				"properties": {
+					"tag": {"$ref": "model.Tag.yaml"},
+					"owner": {"$ref": "user.Owner.yaml"}
+				}
+			},
+			"model.Tag.yaml": {"type": "string"},
+			"user.Owner.yaml": {"type": "object"}
+		}`, string(b))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		_, err := splitDefinitions(map[string]interface{}{}, "file", "definitions", ".json")
+		assert.Error(t, err)
+	})
+}
+
+func TestGen_SplitDefinitions(t *testing.T) {
+	for _, mode := range []string{SplitByPackage, SplitByType} {
+		t.Run(mode, func(t *testing.T) {
+			config := &Config{
+				SearchDir:   searchDir,
+				MainAPIFile: "./main.go",
+				OutputDir:   "../testdata/simple/docs",
+				OutputTypes: []string{"json"},
+			}
+			require.NoError(t, New().Build(config))
+
+			defer os.RemoveAll(config.OutputDir)
+
+			expected, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+			require.NoError(t, err)
+
+			// a stale definition file is removed
+			require.NoError(t, os.MkdirAll(filepath.Join(config.OutputDir, "definitions"), os.ModePerm))
+			require.NoError(t, os.WriteFile(filepath.Join(config.OutputDir, "definitions", "stale.json"), []byte("{}"), 0644))
+
+			config.OutputTypes = []string{"json", "yaml"}
+			config.SplitDefinitions = mode
+			require.NoError(t, New().Build(config))
+
+			assert.NoFileExists(t, filepath.Join(config.OutputDir, "definitions", "stale.json"))
+
+			split, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+			require.NoError(t, err)
+			assert.NotContains(t, string(split), `"definitions":`)
+			assert.Contains(t, string(split), `"$ref": "definitions/`)
+
+			// the bundled document is the one written without split definitions
+			for _, input := range []string{"swagger.json", "swagger.yaml"} {
+				output := filepath.Join(config.OutputDir, "bundled.json")
+				require.NoError(t, New().Bundle(&BundleConfig{
+					Input:  filepath.Join(config.OutputDir, input),
+					Output: output,
+				}))
+
+				bundled, err := os.ReadFile(output)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(expected), string(bundled), input)
+			}
+		})
+	}
+}
+
+func TestGen_SplitDefinitionsInvalidMode(t *testing.T) {
+	config := &Config{
+		SearchDir:        searchDir,
+		MainAPIFile:      "./main.go",
+		OutputDir:        "../testdata/simple/docs",
+		OutputTypes:      []string{"json"},
+		SplitDefinitions: "file",
+	}
+	assert.EqualError(t, New().Build(config), "not supported file splitDefinitions, use package or type")
+}
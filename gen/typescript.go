@@ -0,0 +1,479 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+func (g *Gen) writeTypeScript(config *Config, swagger *spec.Swagger) error {
+	tsFileName := path.Join(config.OutputDir, outputFilename(config, "api.ts"))
+
+	err := g.writeFile(buildTypeScript(swagger), tsFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create api.ts at %+v", tsFileName)
+
+	return nil
+}
+
+// tsBuilder writes the TypeScript declarations of a swagger document.
+type tsBuilder struct {
+	swagger *spec.Swagger
+
+	// names the TypeScript names of the definitions
+	names map[string]string
+
+	buf bytes.Buffer
+}
+
+// buildTypeScript returns TypeScript declarations of the definitions of swagger, and an Operations
+// interface describing the request and responses of its operations, keyed by operation ID.
+func buildTypeScript(swagger *spec.Swagger) []byte {
+	b := &tsBuilder{swagger: swagger, names: tsDefinitionNames(swagger.Definitions)}
+
+	b.buf.WriteString("// Code generated by swaggo/swag. DO NOT EDIT\n")
+
+	definitions := make([]string, 0, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		definitions = append(definitions, name)
+	}
+
+	sort.Strings(definitions)
+
+	for _, name := range definitions {
+		definition := swagger.Definitions[name]
+		b.writeDefinition(b.names[name], &definition)
+	}
+
+	b.writeOperations()
+
+	return b.buf.Bytes()
+}
+
+// tsDefinitionNames names definitions by their type name, eg: "model.Pet" gives "Pet", the package is
+// kept when several definitions have the same type name, eg: "ModelPet" and "V2Pet".
+func tsDefinitionNames(definitions spec.Definitions) map[string]string {
+	short := make(map[string]string, len(definitions))
+	count := make(map[string]int)
+
+	for name := range definitions {
+		short[name] = tsIdentifier(name[strings.Index(name, ".")+1:])
+		count[short[name]]++
+	}
+
+	names := make(map[string]string, len(definitions))
+
+	for name := range definitions {
+		if count[short[name]] > 1 || short[name] == "Operations" || short[name] == "OperationID" {
+			names[name] = tsIdentifier(name)
+		} else {
+			names[name] = short[name]
+		}
+	}
+
+	return names
+}
+
+// tsIdentifier converts name to a TypeScript type name, eg: "model.pet_status" gives "ModelPetStatus".
+func tsIdentifier(name string) string {
+	var identifier strings.Builder
+
+	for _, word := range nonIdentifierCharacters.Split(name, -1) {
+		if word == "" {
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		identifier.WriteString(string(runes))
+	}
+
+	result := identifier.String()
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		result = "T" + result
+	}
+
+	return result
+}
+
+// tsPropertyName quotes name if it is not a valid identifier.
+func tsPropertyName(name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return name
+	}
+
+	b, _ := json.Marshal(name)
+
+	return string(b)
+}
+
+// tsLiteral returns value as a TypeScript literal.
+func tsLiteral(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "unknown"
+	}
+
+	return string(b)
+}
+
+func (b *tsBuilder) writeDefinition(name string, definition *spec.Schema) {
+	b.buf.WriteString("\n")
+	writeJSDoc(&b.buf, "", definition.Description)
+
+	if len(definition.Enum) > 0 {
+		fmt.Fprintf(&b.buf, "export type %s = %s;\n", name, b.schemaType(definition, ""))
+
+		enum := buildReferenceEnum(definition.Enum, definition.Extensions)
+		if len(enum) == 0 || enum[0].Name == "" {
+			return
+		}
+
+		fmt.Fprintf(&b.buf, "\nexport const %s = {\n", name)
+
+		for i, item := range enum {
+			if item.Name == "" {
+				continue
+			}
+
+			writeJSDoc(&b.buf, "  ", item.Description)
+			fmt.Fprintf(&b.buf, "  %s: %s,\n", tsPropertyName(item.Name), tsLiteral(definition.Enum[i]))
+		}
+
+		b.buf.WriteString("} as const;\n")
+
+		return
+	}
+
+	if definition.Ref.String() == "" && len(definition.AllOf) == 0 && len(definition.Properties) > 0 &&
+		definition.AdditionalProperties == nil {
+		fmt.Fprintf(&b.buf, "export interface %s %s\n", name, b.objectType(definition, ""))
+
+		return
+	}
+
+	fmt.Fprintf(&b.buf, "export type %s = %s;\n", name, b.schemaType(definition, ""))
+}
+
+// schemaType returns the TypeScript type of schema, nested object types are indented by indent.
+func (b *tsBuilder) schemaType(schema *spec.Schema, indent string) string {
+	tsType := b.nonNullableType(schema, indent)
+
+	if nullable, ok := schema.Extensions.GetBool("x-nullable"); ok && nullable {
+		return tsType + " | null"
+	}
+
+	return tsType
+}
+
+func (b *tsBuilder) nonNullableType(schema *spec.Schema, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	if ref := schema.Ref.String(); ref != "" {
+		if name, ok := b.names[strings.TrimPrefix(ref, "#/definitions/")]; ok {
+			return name
+		}
+
+		return "unknown"
+	}
+
+	if len(schema.Enum) > 0 {
+		literals := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			literals = append(literals, tsLiteral(value))
+		}
+
+		return strings.Join(literals, " | ")
+	}
+
+	if len(schema.AllOf) > 0 {
+		types := make([]string, 0, len(schema.AllOf))
+		for i := range schema.AllOf {
+			types = append(types, tsGroup(b.schemaType(&schema.AllOf[i], indent)))
+		}
+
+		return strings.Join(types, " & ")
+	}
+
+	switch {
+	case schema.Type.Contains(swag.ARRAY):
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "unknown[]"
+		}
+
+		return tsGroup(b.schemaType(schema.Items.Schema, indent)) + "[]"
+	case schema.Type.Contains(swag.STRING):
+		return "string"
+	case schema.Type.Contains(swag.INTEGER), schema.Type.Contains(swag.NUMBER):
+		return "number"
+	case schema.Type.Contains(swag.BOOLEAN):
+		return "boolean"
+	case schema.Type.Contains("file"):
+		return "Blob"
+	}
+
+	var types []string
+
+	if len(schema.Properties) > 0 {
+		types = append(types, b.objectType(schema, indent))
+	}
+
+	if schema.AdditionalProperties != nil {
+		value := "unknown"
+		if schema.AdditionalProperties.Schema != nil {
+			value = b.schemaType(schema.AdditionalProperties.Schema, indent)
+		}
+
+		types = append(types, "Record<string, "+value+">")
+	}
+
+	if len(types) == 0 {
+		if schema.Type.Contains(swag.OBJECT) {
+			return "Record<string, unknown>"
+		}
+
+		return "unknown"
+	}
+
+	return strings.Join(types, " & ")
+}
+
+// objectType returns the properties of schema as a TypeScript object type, the ones not required are optional.
+func (b *tsBuilder) objectType(schema *spec.Schema, indent string) string {
+	var object strings.Builder
+
+	object.WriteString("{\n")
+
+	for _, name := range sortedProperties(schema) {
+		prop := schema.Properties[name]
+
+		var doc bytes.Buffer
+		writeJSDoc(&doc, indent+"  ", prop.Description)
+		object.Write(doc.Bytes())
+
+		optional := "?"
+		if containsString(schema.Required, name) {
+			optional = ""
+		}
+
+		if prop.ReadOnly {
+			object.WriteString(indent + "  readonly ")
+		} else {
+			object.WriteString(indent + "  ")
+		}
+
+		fmt.Fprintf(&object, "%s%s: %s;\n", tsPropertyName(name), optional, b.schemaType(&prop, indent+"  "))
+	}
+
+	object.WriteString(indent + "}")
+
+	return object.String()
+}
+
+// tsGroup parenthesizes a union or intersection type, so it can be the element of an array or intersection.
+func tsGroup(tsType string) string {
+	if strings.Contains(tsType, " | ") || strings.Contains(tsType, " & ") {
+		return "(" + tsType + ")"
+	}
+
+	return tsType
+}
+
+// parameterType returns the TypeScript type of a parameter.
+func (b *tsBuilder) parameterType(param *spec.Parameter, indent string) string {
+	if param.In == "body" {
+		return b.schemaType(param.Schema, indent)
+	}
+
+	schema := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:   spec.StringOrArray{param.Type},
+			Format: param.Format,
+			Enum:   param.Enum,
+		},
+	}
+
+	if param.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: itemsSchema(param.Items)}
+	}
+
+	return b.schemaType(&schema, indent)
+}
+
+func itemsSchema(items *spec.Items) *spec.Schema {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:   spec.StringOrArray{items.Type},
+			Format: items.Format,
+			Enum:   items.Enum,
+		},
+	}
+
+	if items.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: itemsSchema(items.Items)}
+	}
+
+	return schema
+}
+
+// tsParameterLocations the order the parameters of an operation are listed in, by location.
+var tsParameterLocations = []string{"path", "query", "header", "formData"}
+
+// writeOperations writes the Operations interface, which maps the ID of each operation to its method, path,
+// request and responses. Operations without ID are keyed by their method and path.
+func (b *tsBuilder) writeOperations() {
+	operations := listOperations(b.swagger)
+
+	b.buf.WriteString("\nexport interface Operations {\n")
+
+	for _, op := range operations {
+		key := op.Operation.ID
+		if key == "" {
+			key = op.Method + " " + op.Path
+		}
+
+		writeJSDoc(&b.buf, "  ", op.Operation.Summary)
+
+		if op.Operation.Deprecated {
+			b.buf.WriteString("  /** @deprecated */\n")
+		}
+
+		fmt.Fprintf(&b.buf, "  %s: {\n", tsPropertyName(key))
+		fmt.Fprintf(&b.buf, "    method: %s;\n    path: %s;\n", tsLiteral(op.Method), tsLiteral(op.Path))
+
+		b.writeRequest(op.Operation)
+		b.writeResponses(op.Operation)
+
+		b.buf.WriteString("  };\n")
+	}
+
+	b.buf.WriteString("}\n\nexport type OperationID = keyof Operations;\n")
+}
+
+func (b *tsBuilder) writeRequest(op *spec.Operation) {
+	var request strings.Builder
+
+	for _, in := range tsParameterLocations {
+		var (
+			members  strings.Builder
+			required bool
+		)
+
+		for i := range op.Parameters {
+			param := &op.Parameters[i]
+			if param.In != in {
+				continue
+			}
+
+			optional := "?"
+			if param.Required {
+				optional = ""
+				required = true
+			}
+
+			var doc bytes.Buffer
+			writeJSDoc(&doc, "        ", param.Description)
+			members.Write(doc.Bytes())
+
+			fmt.Fprintf(&members, "        %s%s: %s;\n", tsPropertyName(param.Name), optional, b.parameterType(param, "        "))
+		}
+
+		if members.Len() == 0 {
+			continue
+		}
+
+		optional := "?"
+		if required {
+			optional = ""
+		}
+
+		fmt.Fprintf(&request, "      %s%s: {\n%s      };\n", in, optional, members.String())
+	}
+
+	if body := bodyParameter(op); body != nil {
+		optional := "?"
+		if body.Required {
+			optional = ""
+		}
+
+		fmt.Fprintf(&request, "      body%s: %s;\n", optional, b.parameterType(body, "      "))
+	}
+
+	if request.Len() == 0 {
+		b.buf.WriteString("    request: {};\n")
+
+		return
+	}
+
+	fmt.Fprintf(&b.buf, "    request: {\n%s    };\n", request.String())
+}
+
+func (b *tsBuilder) writeResponses(op *spec.Operation) {
+	if op.Responses == nil || (len(op.Responses.StatusCodeResponses) == 0 && op.Responses.Default == nil) {
+		b.buf.WriteString("    responses: {};\n")
+
+		return
+	}
+
+	b.buf.WriteString("    responses: {\n")
+
+	for _, code := range sortedResponseCodes(op.Responses) {
+		response := op.Responses.StatusCodeResponses[code]
+		b.writeResponse(fmt.Sprint(code), &response)
+	}
+
+	if op.Responses.Default != nil {
+		b.writeResponse("default", op.Responses.Default)
+	}
+
+	b.buf.WriteString("    };\n")
+}
+
+func (b *tsBuilder) writeResponse(key string, response *spec.Response) {
+	writeJSDoc(&b.buf, "      ", response.Description)
+
+	tsType := "void"
+	if response.Schema != nil {
+		tsType = b.schemaType(response.Schema, "      ")
+	}
+
+	fmt.Fprintf(&b.buf, "      %s: %s;\n", key, tsType)
+}
+
+// writeJSDoc writes text as a JSDoc comment.
+func writeJSDoc(buf *bytes.Buffer, indent, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%s/** %s */\n", indent, strings.ReplaceAll(lines[0], "*/", "*\\/"))
+
+		return
+	}
+
+	fmt.Fprintf(buf, "%s/**\n", indent)
+
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s * %s\n", indent, strings.ReplaceAll(strings.TrimSpace(line), "*/", "*\\/"))
+	}
+
+	fmt.Fprintf(buf, "%s */\n", indent)
+}
@@ -0,0 +1,127 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTSIdentifier(t *testing.T) {
+	assert.Equal(t, "ModelPetStatus", tsIdentifier("model.pet_status"))
+	assert.Equal(t, "PageApiPet", tsIdentifier("Page-api_Pet"))
+	assert.Equal(t, "T2fa", tsIdentifier("2fa"))
+}
+
+func TestBuildTypeScript(t *testing.T) {
+	swagger := loadTestPetstore(t)
+
+	swagger.Definitions["model.Status"] = spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Description: "Status of a pet",
+			Type:        spec.StringOrArray{"string"},
+			Enum:        []interface{}{"available", "sold"},
+		},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+			"x-enum-varnames": []interface{}{"StatusAvailable", "StatusSold"},
+			"x-enum-comments": map[string]interface{}{"StatusSold": "no longer available"},
+		}},
+	}
+
+	pet := swagger.Definitions["model.Pet"]
+	pet.Required = []string{"name"}
+	pet.Properties["status"] = *spec.RefProperty("#/definitions/model.Status")
+	pet.Properties["nickname"] = spec.Schema{
+		SchemaProps:      spec.SchemaProps{Type: spec.StringOrArray{"string"}},
+		VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-nullable": true}},
+	}
+	swagger.Definitions["model.Pet"] = pet
+	swagger.Definitions["v2.Pet"] = spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}}}
+
+	getPet := swagger.Paths.Paths["/pets/{id}"].Get
+	getPet.Responses.StatusCodeResponses[200] = spec.Response{
+		ResponseProps: spec.ResponseProps{Description: "OK", Schema: spec.RefProperty("#/definitions/model.Pet")},
+	}
+
+	ts := string(buildTypeScript(swagger))
+
+	// definitions are named by type name, unless it is ambiguous.
+	assert.Contains(t, ts, "export interface ModelPet {\n")
+	assert.Contains(t, ts, "export type V2Pet = Record<string, unknown>;\n")
+	assert.Contains(t, ts, "export interface Owner {\n  pets?: ModelPet[];\n}\n")
+
+	// required and nullable fields.
+	assert.Contains(t, ts, "  name: string;\n")
+	assert.Contains(t, ts, "  nickname?: string | null;\n")
+	assert.Contains(t, ts, "  status?: Status;\n")
+	assert.Contains(t, ts, "  tags?: string[];\n")
+
+	// enums are unions, with constants named by x-enum-varnames.
+	assert.Contains(t, ts, `/** Status of a pet */
+export type Status = "available" | "sold";
+
+export const Status = {
+  StatusAvailable: "available",
+  /** no longer available */
+  StatusSold: "sold",
+} as const;
+`)
+
+	// operations are keyed by ID, or by method and path.
+	assert.Contains(t, ts, `  getPet: {
+    method: "GET";
+    path: "/pets/{id}";
+    request: {
+      path: {
+        id: number;
+      };
+      query?: {
+        verbose?: boolean;
+      };
+    };
+    responses: {
+      /** OK */
+      200: ModelPet;
+    };
+  };
+`)
+	assert.Contains(t, ts, `  addPet: {
+    method: "POST";
+    path: "/pets";
+    request: {
+      header?: {
+        "X-Request-ID"?: string;
+      };
+      body: ModelPet;
+    };
+    responses: {
+      /** Created */
+      201: void;
+    };
+  };
+`)
+	assert.Contains(t, ts, `  "GET /health": {`)
+	assert.Contains(t, ts, "export type OperationID = keyof Operations;\n")
+}
+
+func TestGen_BuildTypeScript(t *testing.T) {
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/simple/docs",
+		OutputTypes: []string{"ts"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	defer os.RemoveAll(config.OutputDir)
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "api.ts"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "export interface Pet {\n")
+	assert.Contains(t, string(b), "  middlename?: string | null;\n")
+	assert.Contains(t, string(b), "export interface Operations {\n")
+}
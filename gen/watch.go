@@ -0,0 +1,234 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+)
+
+// Watch builds the documents like Build, then polls the Go files of the search dirs every interval until stop
+// is closed. Once a poll finds no more changes, only the changed files are read and parsed again, the other
+// ones are kept in memory, and only the part of the document depending on them is built again when possible,
+// see swag.Parser.Reparse.
+// The outputs are rewritten only when the document changed, printing the paths and definitions affected.
+// Parse errors are printed and the changed files are parsed again on the next change.
+func (g *Gen) Watch(config *Config, interval time.Duration, stop <-chan struct{}) error {
+	if config.Check {
+		return fmt.Errorf("watch can't be used with check")
+	}
+
+	p, err := g.parse(config)
+	if err != nil {
+		return err
+	}
+
+	searchDirs := strings.Split(config.SearchDir, ",")
+
+	modTimes, err := p.GoFileModTimes(searchDirs)
+	if err != nil {
+		return err
+	}
+
+	previous, err := json.Marshal(p.GetSwagger())
+	if err != nil {
+		return err
+	}
+
+	if err := g.write(config, p); err != nil {
+		return err
+	}
+
+	g.debug.Printf("Watching %s for changes...", config.SearchDir)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var changes changeBatch
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := p.GoFileModTimes(searchDirs)
+		if err != nil {
+			return err
+		}
+
+		changed := changes.add(changedFiles(modTimes, current))
+		modTimes = current
+
+		if len(changed) == 0 {
+			continue
+		}
+
+		g.debug.Printf("Changed: %s", strings.Join(changed, ", "))
+
+		if err := p.Reparse(searchDirs, config.MainAPIFile, changed); err != nil {
+			g.debug.Printf("error: %s", err)
+
+			changes.failed = changed
+
+			continue
+		}
+
+		doc, err := json.Marshal(p.GetSwagger())
+		if err != nil {
+			return err
+		}
+
+		summary, err := summarizeChanges(previous, doc)
+		if err != nil {
+			return err
+		}
+
+		if summary == "" {
+			g.debug.Printf("No changes in the document")
+
+			continue
+		}
+
+		g.debug.Printf("%s", summary)
+
+		previous = doc
+
+		if err := g.write(config, p); err != nil {
+			g.debug.Printf("error: %s", err)
+		}
+	}
+}
+
+// changeBatch collects the changed files of the polls until one finds no more changes, so saving several files
+// builds the document once.
+type changeBatch struct {
+	// pending the files changed since the last batch
+	pending []string
+
+	// failed the files of the last batch which failed to parse, parsed again with the next batch
+	failed []string
+}
+
+// add adds the files changed since the last poll, and returns the sorted batch of changed files once a poll
+// finds no changes after some.
+func (batch *changeBatch) add(changed []string) []string {
+	if len(changed) > 0 {
+		batch.pending = append(batch.pending, changed...)
+
+		return nil
+	}
+
+	if len(batch.pending) == 0 {
+		return nil
+	}
+
+	files := append(batch.pending, batch.failed...)
+	batch.pending, batch.failed = nil, nil
+
+	sort.Strings(files)
+
+	return slices.Compact(files)
+}
+
+// changedFiles returns the paths of the files added, removed or modified between two modification times.
+func changedFiles(previous, current map[string]time.Time) []string {
+	var changed []string
+
+	for path, modTime := range current {
+		if previousModTime, ok := previous[path]; !ok || !previousModTime.Equal(modTime) {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
+
+// summarizeChanges returns the paths and definitions added, removed or changed between two swagger documents,
+// and whether anything else changed, or an empty string if they are the same.
+func summarizeChanges(previous, current []byte) (string, error) {
+	var before, after spec.Swagger
+
+	if err := json.Unmarshal(previous, &before); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal(current, &after); err != nil {
+		return "", err
+	}
+
+	var lines []string
+
+	var beforePaths, afterPaths map[string]spec.PathItem
+	if before.Paths != nil {
+		beforePaths = before.Paths.Paths
+	}
+
+	if after.Paths != nil {
+		afterPaths = after.Paths.Paths
+	}
+
+	lines = append(lines, summarizeItems("paths", beforePaths, afterPaths)...)
+	lines = append(lines, summarizeItems("definitions", before.Definitions, after.Definitions)...)
+
+	if len(lines) == 0 {
+		before.Paths, after.Paths = nil, nil
+		before.Definitions, after.Definitions = nil, nil
+
+		if reflect.DeepEqual(before, after) {
+			return "", nil
+		}
+
+		lines = append(lines, "general API info changed")
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// summarizeItems returns a line per kind of change of the items named kind between two maps.
+func summarizeItems[T any](kind string, before, after map[string]T) []string {
+	var added, removed, changed []string
+
+	for name, item := range after {
+		previous, ok := before[name]
+		if !ok {
+			added = append(added, name)
+		} else if !reflect.DeepEqual(previous, item) {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	var lines []string
+
+	for _, change := range []struct {
+		verb  string
+		names []string
+	}{{"added", added}, {"removed", removed}, {"changed", changed}} {
+		if len(change.names) == 0 {
+			continue
+		}
+
+		sort.Strings(change.names)
+		lines = append(lines, fmt.Sprintf("%s %s: %s", kind, change.verb, strings.Join(change.names, ", ")))
+	}
+
+	return lines
+}
@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGen_Watch(t *testing.T) {
+	searchDir, err := os.MkdirTemp("../testdata", "watch")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(searchDir)
+
+	mainFile := filepath.Join(searchDir, "main.go")
+
+	require.NoError(t, os.WriteFile(mainFile, []byte(`package main
+
+// @title Swagger Example API
+// @version 1.0
+func main() {}
+
+// @Router /users [get]
+func ListUsers() {}
+`), 0644))
+
+	var buf bytes.Buffer
+
+	config := &Config{
+		SearchDir:   searchDir,
+		MainAPIFile: "./main.go",
+		OutputDir:   filepath.Join(searchDir, "docs"),
+		OutputTypes: []string{"json"},
+		Debugger:    log.New(&buf, "", 0),
+	}
+
+	stop := make(chan struct{})
+	done := make(chan error)
+
+	go func() {
+		done <- New().Watch(config, 10*time.Millisecond, stop)
+	}()
+
+	paths := func() map[string]spec.PathItem {
+		var swagger spec.Swagger
+
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+		if err != nil || json.Unmarshal(b, &swagger) != nil || swagger.Paths == nil {
+			return nil
+		}
+
+		return swagger.Paths.Paths
+	}
+
+	assert.Eventually(t, func() bool { return len(paths()) == 1 }, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(filepath.Join(searchDir, "orders.go"), []byte(`package main
+
+type Order struct {
+	ID int `+"`json:\"id\"`"+`
+}
+
+// @Success 200 {array} Order
+// @Router /orders [get]
+func ListOrders() {}
+`), 0644))
+
+	assert.Eventually(t, func() bool { return len(paths()) == 2 }, 10*time.Second, 10*time.Millisecond)
+
+	close(stop)
+	require.NoError(t, <-done)
+
+	assert.Contains(t, buf.String(), "paths added: /orders")
+	assert.Contains(t, buf.String(), "definitions added: main.Order")
+
+	config.Check = true
+	assert.EqualError(t, New().Watch(config, time.Second, nil), "watch can't be used with check")
+}
+
+func TestChangeBatch(t *testing.T) {
+	var batch changeBatch
+
+	// a save of several files across polls is a single batch
+	assert.Nil(t, batch.add([]string{"b.go"}))
+	assert.Nil(t, batch.add([]string{"a.go", "b.go"}))
+	assert.Equal(t, []string{"a.go", "b.go"}, batch.add(nil))
+	assert.Nil(t, batch.add(nil))
+
+	// the files which failed to parse are parsed again with the next batch only
+	batch.failed = []string{"a.go"}
+	assert.Nil(t, batch.add(nil))
+	assert.Nil(t, batch.add([]string{"c.go"}))
+	assert.Equal(t, []string{"a.go", "c.go"}, batch.add(nil))
+	assert.Nil(t, batch.failed)
+}
+
+func TestSummarizeChanges(t *testing.T) {
+	before := []byte(`{"info":{"version":"1.0"},"paths":{"/a":{"get":{}},"/b":{"get":{}}},"definitions":{"A":{"type":"object"}}}`)
+
+	summary, err := summarizeChanges(before, before)
+	assert.NoError(t, err)
+	assert.Empty(t, summary)
+
+	after := []byte(`{"info":{"version":"1.0"},"paths":{"/a":{"post":{}},"/c":{"get":{}}},"definitions":{"A":{"type":"object"},"B":{"type":"object"}}}`)
+
+	summary, err = summarizeChanges(before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, "paths added: /c\npaths removed: /b\npaths changed: /a\ndefinitions added: B", summary)
+
+	summary, err = summarizeChanges(before, []byte(`{"info":{"version":"2.0"},"paths":{"/a":{"get":{}},"/b":{"get":{}}},"definitions":{"A":{"type":"object"}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "general API info changed", summary)
+}
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+
+	previous := map[string]time.Time{"a.go": now, "b.go": now, "c.go": now}
+	current := map[string]time.Time{"a.go": now, "b.go": now.Add(time.Second), "d.go": now}
+
+	assert.ElementsMatch(t, []string{"b.go", "c.go", "d.go"}, changedFiles(previous, current))
+}
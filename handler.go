@@ -0,0 +1,378 @@
+package swag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	jsonContentType = "application/json; charset=utf-8"
+	yamlContentType = "application/yaml; charset=utf-8"
+)
+
+var yamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"}
+
+// HandlerOption configures the Handler.
+type HandlerOption func(*handler)
+
+// TrustForwardedHeaders rewrites host, basePath and schemes from the X-Forwarded-Host, X-Forwarded-Prefix
+// and X-Forwarded-Proto headers when present. It must only be set behind a proxy which sets or removes them,
+// since any client can send them.
+func TrustForwardedHeaders() HandlerOption {
+	return func(h *handler) {
+		h.trustForwarded = true
+	}
+}
+
+// Handler returns a http.Handler serving the swagger document registered with given name.
+// The document is served as YAML if the request path ends with .yaml or .yml, or if the
+// Accept header asks for YAML, and as JSON otherwise.
+// The served bytes of a Spec and their ETag are computed once until the document changes.
+func Handler(name string, options ...HandlerOption) http.Handler {
+	h := &handler{
+		name:  name,
+		cache: make(map[string]*servedDoc),
+	}
+
+	for _, option := range options {
+		option(h)
+	}
+
+	return h
+}
+
+type handler struct {
+	name           string
+	trustForwarded bool
+
+	mutex sync.Mutex
+
+	// cache the documents served for the cached ones of a Spec, by content type
+	cache map[string]*servedDoc
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	swagger := GetSwagger(h.name)
+	if swagger == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	contentType := jsonContentType
+	if wantsYAML(r) {
+		contentType = yamlContentType
+	}
+
+	doc, err := h.document(swagger, contentType, r.Header)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	if h.trustForwarded {
+		w.Header().Set("Vary", "Accept, Accept-Encoding, X-Forwarded-Host, X-Forwarded-Prefix, X-Forwarded-Proto")
+	} else {
+		w.Header().Set("Vary", "Accept, Accept-Encoding")
+	}
+
+	serveDoc(w, r, contentType, doc)
+}
+
+// document returns the document of swagger to serve with contentType, the cached one unless it is
+// rewritten from the forwarded headers or isn't a Spec.
+func (h *handler) document(swagger Swagger, contentType string, header http.Header) (*servedDoc, error) {
+	s, ok := swagger.(*Spec)
+	if !ok || h.trustForwarded && isForwarded(header) {
+		doc, err := readDoc(swagger, contentType, header, h.trustForwarded)
+		if err != nil {
+			return nil, err
+		}
+
+		return newServedDoc(doc), nil
+	}
+
+	var (
+		src []byte
+		err error
+	)
+
+	if contentType == yamlContentType {
+		src, err = s.cachedYAML()
+	} else {
+		src, err = s.cachedJSON()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	doc := h.cache[contentType]
+	if doc == nil || !sameBytes(doc.body, src) {
+		doc = newServedDoc(src)
+		h.cache[contentType] = doc
+	}
+
+	return doc, nil
+}
+
+// readDoc returns the document of swagger with contentType, rewritten from the forwarded headers if trusted.
+func readDoc(swagger Swagger, contentType string, header http.Header, trustForwarded bool) ([]byte, error) {
+	doc, err := readDocJSON(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	if trustForwarded {
+		doc, err = rewriteForwarded(doc, header)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if contentType == yamlContentType {
+		return yaml.JSONToYAML(doc)
+	}
+
+	return doc, nil
+}
+
+// sameBytes reports whether a and b are the same slice, and not only equal.
+func sameBytes(a, b []byte) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// IndexHandler returns a http.Handler listing the names of all registered swagger documents as a JSON array.
+func IndexHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		swaggerMu.RLock()
+		names := make([]string, 0, len(swags))
+		for name := range swags {
+			names = append(names, name)
+		}
+		swaggerMu.RUnlock()
+
+		sort.Strings(names)
+
+		doc, _ := json.Marshal(names)
+
+		w.Header().Set("Vary", "Accept-Encoding")
+		serveDoc(w, r, jsonContentType, newServedDoc(doc))
+	})
+}
+
+// readDocJSON returns the document of swagger as JSON, using the cached one of Spec.
+func readDocJSON(swagger Swagger) ([]byte, error) {
+	if s, ok := swagger.(*Spec); ok {
+		return s.cachedJSON()
+	}
+
+	return []byte(swagger.ReadDoc()), nil
+}
+
+func isForwarded(header http.Header) bool {
+	return header.Get("X-Forwarded-Host") != "" || header.Get("X-Forwarded-Prefix") != "" ||
+		header.Get("X-Forwarded-Proto") != ""
+}
+
+func rewriteForwarded(doc []byte, header http.Header) ([]byte, error) {
+	if !isForwarded(header) {
+		return doc, nil
+	}
+
+	host := header.Get("X-Forwarded-Host")
+	prefix := header.Get("X-Forwarded-Prefix")
+	proto := header.Get("X-Forwarded-Proto")
+
+	var swagger spec.Swagger
+
+	err := json.Unmarshal(doc, &swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	if host != "" {
+		// only the first proxy is relevant to the client.
+		swagger.Host = strings.TrimSpace(strings.Split(host, ",")[0])
+	}
+
+	if prefix != "" {
+		swagger.BasePath = path.Join("/", strings.TrimSpace(strings.Split(prefix, ",")[0]), swagger.BasePath)
+	}
+
+	if proto != "" {
+		swagger.Schemes = []string{strings.TrimSpace(strings.Split(proto, ",")[0])}
+	}
+
+	return json.MarshalIndent(&swagger, "", "    ")
+}
+
+func wantsYAML(r *http.Request) bool {
+	switch path.Ext(r.URL.Path) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+
+	accept := r.Header.Get("Accept")
+	for _, mediaType := range yamlMediaTypes {
+		if strings.Contains(accept, mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// servedDoc is a document with its strong ETags, computed once for the document.
+type servedDoc struct {
+	body []byte
+	etag string
+
+	gzipOnce sync.Once
+	gzipped  []byte
+}
+
+func newServedDoc(body []byte) *servedDoc {
+	sum := sha256.Sum256(body)
+
+	return &servedDoc{
+		body: body,
+		etag: hex.EncodeToString(sum[:16]),
+	}
+}
+
+// gzipBody returns the body compressed with gzip, compressing it on first call.
+func (doc *servedDoc) gzipBody() []byte {
+	doc.gzipOnce.Do(func() {
+		var buf bytes.Buffer
+
+		gz := gzip.NewWriter(&buf)
+		_, _ = gz.Write(doc.body)
+		_ = gz.Close()
+
+		doc.gzipped = buf.Bytes()
+	})
+
+	return doc.gzipped
+}
+
+// serveDoc writes doc with a strong ETag, answering conditional requests with 304 Not Modified
+// and compressing the body with gzip if the client accepts it.
+func serveDoc(w http.ResponseWriter, r *http.Request, contentType string, doc *servedDoc) {
+	etag := doc.etag
+
+	useGzip := acceptsGzip(r.Header.Get("Accept-Encoding"))
+	if useGzip {
+		// a strong ETag must differ between encodings of the same document.
+		etag += "-gzip"
+	}
+
+	etag = `"` + etag + `"`
+
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("ETag", etag)
+
+	if matchETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+
+		return
+	}
+
+	if !useGzip {
+		_, _ = w.Write(doc.body)
+
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+
+	_, _ = w.Write(doc.gzipBody())
+}
+
+// acceptsGzip reports whether an Accept-Encoding header accepts gzip, by name or by *, with a non zero q-value.
+func acceptsGzip(acceptEncoding string) bool {
+	accepted := false
+
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+
+		quality := 1.0
+
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(param, "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), "q") {
+				q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil {
+					q = 0
+				}
+
+				quality = q
+			}
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "gzip":
+			// an explicit gzip coding takes precedence over *
+			return quality > 0
+		case "*":
+			accepted = quality > 0
+		}
+	}
+
+	return accepted
+}
+
+// matchETag reports whether an If-None-Match header matches etag, with the weak comparison
+// If-None-Match requires (RFC 9110 §13.1.2): a W/ prefix is ignored on both sides.
+func matchETag(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+package swag
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	setup()
+	Register(Name, &Spec{Host: "localhost:8080", SwaggerTemplate: testSpecTemplate})
+
+	handler := Handler(Name)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, jsonContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"host": "localhost:8080"`)
+
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Header.Set("If-None-Match", "W/"+etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil))
+	assert.Equal(t, yamlContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "host: localhost:8080")
+
+	req = httptest.NewRequest(http.MethodGet, "/swagger/doc", nil)
+	req.Header.Set("Accept", "application/yaml")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, yamlContentType, w.Header().Get("Content-Type"))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/swagger/doc.json", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	Handler("unknown").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_Gzip(t *testing.T) {
+	setup()
+	Register(Name, &s{})
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	w := httptest.NewRecorder()
+	Handler(Name).ServeHTTP(w, req)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Regexp(t, `^"[0-9a-f]{32}-gzip"$`, w.Header().Get("ETag"))
+
+	gz, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, string(body))
+
+	req.Header.Set("Accept-Encoding", "gzip;q=0, deflate")
+	w = httptest.NewRecorder()
+	Handler(Name).ServeHTTP(w, req)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, doc, w.Body.String())
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"":                      false,
+		"gzip":                  true,
+		"deflate, GZIP;q=0.5":   true,
+		"gzip;q=0":              false,
+		"gzip; q=0.0, *":        false,
+		"*":                     true,
+		"*;q=0":                 false,
+		"deflate, br":           false,
+		"gzip;q=invalid":        false,
+		"identity;q=1, *;q=0.1": true,
+	}
+
+	for acceptEncoding, want := range tests {
+		assert.Equal(t, want, acceptsGzip(acceptEncoding), acceptEncoding)
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"":                  false,
+		`"abc"`:             true,
+		`W/"abc"`:           true,
+		`"xyz", W/"abc"`:    true,
+		` W/"xyz" , "abc" `: true,
+		"*":                 true,
+		`"xyz"`:             false,
+		`W/"xyz", "abcd"`:   false,
+		`w/"abc"`:           false,
+	}
+
+	for ifNoneMatch, want := range tests {
+		assert.Equal(t, want, matchETag(ifNoneMatch, `"abc"`), ifNoneMatch)
+	}
+}
+
+func TestHandler_Forwarded(t *testing.T) {
+	setup()
+	Register(Name, &Spec{Host: "localhost:8080", SwaggerTemplate: testSpecTemplate})
+
+	req := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	req.Header.Set("X-Forwarded-Host", "api.example.com, proxy.internal")
+	req.Header.Set("X-Forwarded-Prefix", "/pets-service")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	Handler(Name, TrustForwardedHeaders()).ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"host": "api.example.com"`)
+	assert.Contains(t, w.Body.String(), `"basePath": "/pets-service"`)
+	assert.Contains(t, w.Body.String(), `"schemes": [`)
+
+	// the registered document is left unchanged.
+	d, _ := ReadDoc()
+	assert.Contains(t, d, `"host": "localhost:8080"`)
+
+	// the headers are ignored unless trusted.
+	w = httptest.NewRecorder()
+	Handler(Name).ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"host": "localhost:8080"`)
+	assert.NotContains(t, w.Body.String(), "pets-service")
+	assert.Equal(t, "Accept, Accept-Encoding", w.Header().Get("Vary"))
+}
+
+func TestHandler_Cached(t *testing.T) {
+	setup()
+
+	doc := &Spec{Host: "localhost:8080", SwaggerTemplate: testSpecTemplate}
+	Register(Name, doc)
+
+	h := Handler(Name)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	etag := w.Header().Get("ETag")
+
+	served := h.(*handler).cache[jsonContentType]
+	cached, err := doc.cachedJSON()
+	assert.NoError(t, err)
+	assert.True(t, sameBytes(cached, served.body))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Same(t, served, h.(*handler).cache[jsonContentType])
+
+	assert.NoError(t, doc.Update(func(doc *spec.Swagger) error {
+		doc.Host = "api.example.com"
+
+		return nil
+	}))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"host": "api.example.com"`)
+}
+
+func TestIndexHandler(t *testing.T) {
+	setup()
+	Register(Name, &s{})
+	Register("admin", &s{})
+
+	w := httptest.NewRecorder()
+	IndexHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `["admin","swagger"]`, w.Body.String())
+}
@@ -0,0 +1,229 @@
+// Package load loads the Go packages swag parses with golang.org/x/tools/go/packages.
+package load
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// depsMode loads the files and the imports of the packages and of all their dependencies.
+	depsMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule
+
+	// typedMode loads the syntax and the type information of the packages, along with everything depsMode loads
+	// so that the packages it loads are dependencies too.
+	typedMode = depsMode | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo
+)
+
+// Loader loads packages the way the go command builds them, honouring go.work, replace directives,
+// build tags, GOFLAGS and vendor mode. The packages type checked for the gin handlers are dependencies
+// too, so a package needed by both is loaded once.
+type Loader struct {
+	dir  string
+	tags []string
+	env  []string
+
+	// load is packages.Load, replaced by the tests counting the loads
+	load func(config *packages.Config, patterns ...string) ([]*packages.Package, error)
+
+	mutex sync.Mutex
+
+	// deps the packages loaded with or without syntax, by import path
+	deps map[string]*packages.Package
+
+	// dirs the packages of deps, by dir
+	dirs map[string]*packages.Package
+
+	// typed the packages loaded with their syntax and types, by dir and patterns
+	typed map[string][]*packages.Package
+}
+
+// New creates a Loader resolving the import paths in dir, with the build tags and the environment variables
+// added to the ones of the process, eg: GOOS.
+func New(dir string, tags []string, env []string) *Loader {
+	return &Loader{
+		dir:   dir,
+		tags:  tags,
+		env:   env,
+		load:  packages.Load,
+		deps:  make(map[string]*packages.Package),
+		dirs:  make(map[string]*packages.Package),
+		typed: make(map[string][]*packages.Package),
+	}
+}
+
+func (loader *Loader) config(dir string, mode packages.LoadMode) *packages.Config {
+	config := &packages.Config{
+		Mode: mode,
+		Dir:  dir,
+	}
+
+	if len(loader.env) > 0 {
+		config.Env = append(os.Environ(), loader.env...)
+	}
+
+	if len(loader.tags) > 0 {
+		config.BuildFlags = []string{"-tags=" + strings.Join(loader.tags, ",")}
+	}
+
+	return config
+}
+
+// Deps returns the packages of patterns resolved in dir, with their dependencies as imports.
+// The patterns naming the dirs of packages already loaded, eg: ".", are not loaded again.
+func (loader *Loader) Deps(dir string, patterns ...string) ([]*packages.Package, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	if pkgs, ok := loader.loaded(dir, patterns); ok {
+		return pkgs, nil
+	}
+
+	return loader.loadDeps(dir, patterns...)
+}
+
+// loaded returns the packages of patterns if all of them are the dirs of packages already loaded.
+func (loader *Loader) loaded(dir string, patterns []string) ([]*packages.Package, bool) {
+	pkgs := make([]*packages.Package, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		if pattern != "." && !strings.HasPrefix(pattern, "./") && !strings.HasPrefix(pattern, "../") ||
+			strings.Contains(pattern, "...") {
+			return nil, false
+		}
+
+		absDir, err := filepath.Abs(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, false
+		}
+
+		pkg, ok := loader.dirs[absDir]
+		if !ok {
+			return nil, false
+		}
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	return pkgs, true
+}
+
+func (loader *Loader) loadDeps(dir string, patterns ...string) ([]*packages.Package, error) {
+	pkgs, err := loader.load(loader.config(dir, depsMode), patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	loader.add(pkgs)
+
+	return pkgs, nil
+}
+
+// add adds pkgs and their dependencies to the packages loaded, keeping the ones loaded before.
+func (loader *Loader) add(pkgs []*packages.Package) {
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if _, ok := loader.deps[pkg.PkgPath]; ok {
+			return
+		}
+
+		loader.deps[pkg.PkgPath] = pkg
+
+		if pkg.Dir != "" {
+			loader.dirs[pkg.Dir] = pkg
+		}
+	})
+}
+
+// Package returns the package of importPath, with its dependencies as imports.
+// It is loaded only if it wasn't loaded before, by Deps or Typed.
+func (loader *Loader) Package(importPath string) (*packages.Package, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	if pkg, ok := loader.deps[importPath]; ok {
+		return pkg, nil
+	}
+
+	pkgs, err := loader.loadDeps(loader.dir, importPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := loader.deps[importPath]
+	if !ok || len(pkgs) == 0 {
+		return nil, fmt.Errorf("package %s not found", importPath)
+	}
+
+	if len(pkg.Errors) > 0 && len(pkg.GoFiles) == 0 {
+		return nil, pkg.Errors[0]
+	}
+
+	return pkg, nil
+}
+
+// Typed returns the packages of patterns resolved in dir with their syntax and type information,
+// loading them once until Reset. They and their dependencies are returned by Deps and Package too.
+func (loader *Loader) Typed(dir string, patterns ...string) ([]*packages.Package, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	key := dir + "\x00" + strings.Join(patterns, "\x00")
+
+	if pkgs, ok := loader.typed[key]; ok {
+		return pkgs, nil
+	}
+
+	pkgs, err := loader.load(loader.config(dir, typedMode), patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	loader.typed[key] = pkgs
+	loader.add(pkgs)
+
+	return pkgs, nil
+}
+
+// Reset forgets the packages loaded, to load them again once their files changed.
+func (loader *Loader) Reset() {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	loader.deps = make(map[string]*packages.Package)
+	loader.dirs = make(map[string]*packages.Package)
+	loader.typed = make(map[string][]*packages.Package)
+}
+
+// Imports returns the imports of pkg sorted by import path.
+func Imports(pkg *packages.Package) []*packages.Package {
+	paths := make([]string, 0, len(pkg.Imports))
+	for path := range pkg.Imports {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	imports := make([]*packages.Package, 0, len(paths))
+	for _, path := range paths {
+		imports = append(imports, pkg.Imports[path])
+	}
+
+	return imports
+}
+
+// IsStandard reports whether pkg is a package of the standard library.
+func IsStandard(pkg *packages.Package) bool {
+	if pkg.Module != nil {
+		return false
+	}
+
+	first, _, _ := strings.Cut(pkg.PkgPath, "/")
+
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,59 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoaderSharesLoads(t *testing.T) {
+	t.Setenv("GOWORK", "off")
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":         "module example.com/shared\n\ngo 1.21\n",
+		"main.go":        "package main\n\nimport _ \"example.com/shared/models\"\n\nfunc main() {}\n",
+		"models/user.go": "package models\n\ntype User struct {\n\tName string\n}\n",
+	}
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	loader := New(dir, nil, nil)
+
+	loads := 0
+	loader.load = func(config *packages.Config, patterns ...string) ([]*packages.Package, error) {
+		loads++
+
+		return packages.Load(config, patterns...)
+	}
+
+	typed, err := loader.Typed(dir, "./...")
+	require.NoError(t, err)
+	assert.Len(t, typed, 2)
+
+	roots, err := loader.Deps(dir, ".")
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	assert.Equal(t, "example.com/shared", roots[0].PkgPath)
+	assert.Contains(t, roots[0].Imports, "example.com/shared/models")
+
+	models, err := loader.Package("example.com/shared/models")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "models", "user.go")}, models.GoFiles)
+
+	assert.Equal(t, 1, loads)
+
+	loader.Reset()
+
+	_, err = loader.Deps(dir, ".")
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
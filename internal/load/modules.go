@@ -0,0 +1,168 @@
+package load
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Module is a module by path and directory.
+type Module struct {
+	Path string
+	Dir  string
+}
+
+// Contains reports whether the package of importPath belongs to the module.
+func (module Module) Contains(importPath string) bool {
+	return importPath == module.Path || strings.HasPrefix(importPath, module.Path+"/")
+}
+
+// Modules finds the modules of the directories from their go.mod files, the way the go command does.
+type Modules struct {
+	mutex sync.Mutex
+
+	// modules the module of the directories looked up, nil outside modules
+	modules map[string]*Module
+}
+
+// NewModules creates a Modules.
+func NewModules() *Modules {
+	return &Modules{
+		modules: make(map[string]*Module),
+	}
+}
+
+// PackagePath returns the import path of the package in dir, from the closest go.mod file,
+// or false if dir isn't in a module or modules are disabled with GO111MODULE=off.
+func (modules *Modules) PackagePath(dir string) (string, bool, error) {
+	if os.Getenv("GO111MODULE") == "off" {
+		return "", false, nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false, err
+	}
+
+	modules.mutex.Lock()
+	defer modules.mutex.Unlock()
+
+	module, err := modules.find(dir)
+	if err != nil || module == nil {
+		return "", false, err
+	}
+
+	relPath, err := filepath.Rel(module.Dir, dir)
+	if err != nil {
+		return "", false, err
+	}
+
+	return path.Join(module.Path, filepath.ToSlash(relPath)), true, nil
+}
+
+func (modules *Modules) find(dir string) (*Module, error) {
+	if module, ok := modules.modules[dir]; ok {
+		return module, nil
+	}
+
+	var module *Module
+
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	switch {
+	case err == nil:
+		modulePath := modfile.ModulePath(data)
+		if modulePath == "" {
+			return nil, fmt.Errorf("no module declaration in %s", filepath.Join(dir, "go.mod"))
+		}
+
+		module = &Module{Path: modulePath, Dir: dir}
+	case !os.IsNotExist(err):
+		return nil, err
+	default:
+		if parent := filepath.Dir(dir); parent != dir {
+			module, err = modules.find(parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	modules.modules[dir] = module
+
+	return module, nil
+}
+
+// WorkspaceModules returns the modules of the go.work file the go command uses in dir: the one of GOWORK,
+// or the closest one, or nil if there is none or workspaces are disabled with GOWORK=off.
+func WorkspaceModules(dir string) ([]Module, error) {
+	file, err := workFile(dir)
+	if err != nil || file == "" {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(file, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]Module, 0, len(work.Use))
+
+	for _, use := range work.Use {
+		moduleDir := use.Path
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(filepath.Dir(file), moduleDir)
+		}
+
+		data, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+		if err != nil {
+			return nil, err
+		}
+
+		modules = append(modules, Module{Path: modfile.ModulePath(data), Dir: filepath.Clean(moduleDir)})
+	}
+
+	return modules, nil
+}
+
+func workFile(dir string) (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "", "auto":
+	default:
+		return gowork, nil
+	}
+
+	if os.Getenv("GO111MODULE") == "off" {
+		return "", nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		file := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
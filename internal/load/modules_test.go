@@ -0,0 +1,76 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulesPackagePath(t *testing.T) {
+	t.Setenv("GO111MODULE", "")
+
+	modules := NewModules()
+
+	pkgPath, ok, err := modules.PackagePath("../../testdata/workspace/models/tags")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "example.com/workspace/models/tags", pkgPath)
+
+	pkgPath, ok, err = modules.PackagePath("../../testdata/workspace")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/swaggo/swag/testdata/workspace", pkgPath)
+
+	pkgPath, ok, err = modules.PackagePath(".")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/swaggo/swag/internal/load", pkgPath)
+
+	_, ok, err = NewModules().PackagePath(t.TempDir())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	t.Setenv("GO111MODULE", "off")
+
+	_, ok, err = NewModules().PackagePath(".")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestWorkspaceModules(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	dir, err := filepath.Abs("../../testdata/workspace")
+	require.NoError(t, err)
+
+	expected := []Module{
+		{Path: "example.com/workspace/api", Dir: filepath.Join(dir, "api")},
+		{Path: "example.com/workspace/models", Dir: filepath.Join(dir, "models")},
+	}
+
+	modules, err := WorkspaceModules(filepath.Join(dir, "api"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, modules)
+
+	assert.True(t, modules[1].Contains("example.com/workspace/models/tags"))
+	assert.False(t, modules[1].Contains("example.com/workspace/modelsx"))
+
+	modules, err = WorkspaceModules(".")
+	require.NoError(t, err)
+	assert.Empty(t, modules)
+
+	t.Setenv("GOWORK", filepath.Join(dir, "go.work"))
+
+	modules, err = WorkspaceModules(os.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, expected, modules)
+
+	t.Setenv("GOWORK", "off")
+
+	modules, err = WorkspaceModules(filepath.Join(dir, "api"))
+	require.NoError(t, err)
+	assert.Empty(t, modules)
+}
@@ -0,0 +1,49 @@
+package swag
+
+import (
+	"runtime"
+	"sync"
+)
+
+// forEachJob calls job with each index of [0, n) on at most jobs goroutines, and waits for them to return.
+// The jobs run sequentially in the order of the indexes if jobs is 1, on as many goroutines as CPUs if it is 0.
+func forEachJob(jobs, n int, job func(i int)) {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	if jobs > n {
+		jobs = n
+	}
+
+	if jobs <= 1 {
+		for i := 0; i < n; i++ {
+			job(i)
+		}
+
+		return
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	wg.Add(jobs)
+
+	for w := 0; w < jobs; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				job(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+}
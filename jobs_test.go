@@ -0,0 +1,63 @@
+package swag
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachJob(t *testing.T) {
+	t.Parallel()
+
+	for _, jobs := range []int{0, 1, 3, 100} {
+		var calls int64
+
+		done := make([]bool, 10)
+
+		forEachJob(jobs, len(done), func(i int) {
+			atomic.AddInt64(&calls, 1)
+			done[i] = true
+		})
+
+		assert.Equal(t, int64(10), calls)
+		assert.NotContains(t, done, false)
+	}
+
+	var order []int
+
+	forEachJob(1, 3, func(i int) {
+		order = append(order, i)
+	})
+
+	assert.Equal(t, []int{0, 1, 2}, order)
+}
+
+func TestParseJobs(t *testing.T) {
+	for _, searchDir := range []string{
+		"testdata/pet",
+		"testdata/enums",
+		"testdata/single_file_api",
+		"testdata/visibility",
+		"testdata/jsonschema",
+	} {
+		t.Run(searchDir, func(t *testing.T) {
+			p := New(SetJobs(1), SetParseDependency(1))
+			require.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+			expected, err := json.MarshalIndent(p.GetSwagger(), "", "    ")
+			require.NoError(t, err)
+
+			for i := 0; i < 3; i++ {
+				p = New(SetJobs(8), SetParseDependency(1))
+				require.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+				doc, err := json.MarshalIndent(p.GetSwagger(), "", "    ")
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), string(doc))
+			}
+		})
+	}
+}
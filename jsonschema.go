@@ -0,0 +1,142 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// JSONSchemaDefinitions returns the names of the definitions of the types selected for the JSON Schema output,
+// by @JSONSchema annotation or by SetJSONSchemaTypes, and the definitions they refer to which are not
+// in the swagger document since no operation uses them.
+func (parser *Parser) JSONSchemaDefinitions() ([]string, spec.Definitions) {
+	return parser.jsonSchemaRoots, parser.jsonSchemaDefinitions
+}
+
+// parseJSONSchemaTypes parses the types selected for the JSON Schema output, whether or not an operation uses them.
+// The definitions added along are moved out of the swagger document, so the selection doesn't change it.
+func (parser *Parser) parseJSONSchemaTypes() error {
+	existing := make(map[string]bool, len(parser.swagger.Definitions))
+	for name := range parser.swagger.Definitions {
+		existing[name] = true
+	}
+
+	err := parser.packages.RangeFiles(func(info *AstFileInfo) error {
+		for _, astDeclaration := range info.File.Decls {
+			generalDeclaration, ok := astDeclaration.(*ast.GenDecl)
+			if !ok || generalDeclaration.Tok != token.TYPE {
+				continue
+			}
+
+			for _, astSpec := range generalDeclaration.Specs {
+				typeSpec, ok := astSpec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				if !hasJSONSchemaAnnotation(typeSpec.Doc) &&
+					!(len(generalDeclaration.Specs) == 1 && hasJSONSchemaAnnotation(generalDeclaration.Doc)) {
+					continue
+				}
+
+				if err := parser.addJSONSchemaType(typeSpec.Name.Name, info.File); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, typeName := range parser.jsonSchemaTypes {
+		var typeSpecDef *TypeSpecDef
+
+		if separator := strings.LastIndex(typeName, "."); strings.Contains(typeName, "/") && separator != -1 {
+			typeSpecDef = parser.packages.findTypeSpec(typeName[:separator], typeName[separator+1:])
+		} else {
+			typeSpecDef = parser.packages.FindTypeSpec(typeName, nil)
+		}
+
+		if typeSpecDef == nil {
+			return fmt.Errorf("cannot find type definition: %s", typeName)
+		}
+
+		if err := parser.addJSONSchemaType(typeSpecDef.Name(), typeSpecDef.File); err != nil {
+			return err
+		}
+	}
+
+	for name, definition := range parser.swagger.Definitions {
+		if existing[name] {
+			continue
+		}
+
+		if parser.jsonSchemaDefinitions == nil {
+			parser.jsonSchemaDefinitions = make(spec.Definitions)
+		}
+
+		parser.jsonSchemaDefinitions[name] = definition
+		delete(parser.swagger.Definitions, name)
+	}
+
+	return nil
+}
+
+// addJSONSchemaType parses the type typeName of file and selects its definition for the JSON Schema output.
+func (parser *Parser) addJSONSchemaType(typeName string, file *ast.File) error {
+	typeSpecDef := parser.packages.FindTypeSpec(typeName, file)
+	if typeSpecDef == nil {
+		return fmt.Errorf("cannot find type definition: %s", typeName)
+	}
+
+	if typeSpecDef.TypeSpec.TypeParams != nil {
+		parser.debug.Printf("Skipping '%s', generic types can't be selected for JSON Schema.", typeSpecDef.FullPath())
+
+		return nil
+	}
+
+	schema, err := parser.getTypeSchema(typeName, file, true)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimPrefix(schema.Ref.String(), "#/definitions/")
+	if name == "" {
+		// simple types are inlined rather than referred to, they get a definition of their own
+		name = typeSpecDef.SchemaName
+		if _, ok := parser.swagger.Definitions[name]; !ok {
+			parser.swagger.Definitions[name] = *schema
+		}
+	}
+
+	for _, root := range parser.jsonSchemaRoots {
+		if root == name {
+			return nil
+		}
+	}
+
+	parser.jsonSchemaRoots = append(parser.jsonSchemaRoots, name)
+
+	return nil
+}
+
+func hasJSONSchemaAnnotation(commentGroup *ast.CommentGroup) bool {
+	if commentGroup == nil {
+		return false
+	}
+
+	for _, comment := range commentGroup.List {
+		fields := FieldsByAnySpace(strings.TrimSpace(strings.TrimLeft(comment.Text, "/")), 2)
+		if len(fields) > 0 && strings.ToLower(fields[0]) == jsonSchemaAttr {
+			return true
+		}
+	}
+
+	return false
+}
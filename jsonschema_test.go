@@ -0,0 +1,73 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_JSONSchemaDefinitions(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string
+}
+
+// Event published on the event pipeline.
+// @JSONSchema
+type Event struct {
+	ID      string
+	Payload Payload
+}
+
+type Payload struct {
+	User User
+}
+
+// @JSONSchema
+type Level string
+
+type Config struct {
+	Rules []string
+}
+
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser() {}
+`
+	p := New(SetJSONSchemaTypes([]string{"api.Config"}))
+	_ = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	require.NoError(t, err)
+
+	require.NoError(t, p.parseJSONSchemaTypes())
+
+	roots, definitions := p.JSONSchemaDefinitions()
+	assert.Equal(t, []string{"api.Event", "api.Level", "api.Config"}, roots)
+
+	// the selected types don't change the swagger document.
+	assert.Len(t, p.swagger.Definitions, 1)
+	assert.Contains(t, p.swagger.Definitions, "api.User")
+
+	assert.Len(t, definitions, 4)
+	assert.Contains(t, definitions, "api.Payload")
+	assert.Equal(t, "string", definitions["api.Level"].Type[0])
+}
+
+func TestParser_JSONSchemaDefinitionsNotFound(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetJSONSchemaTypes([]string{"api.Missing"}))
+	_ = p.packages.ParseFile("api", "api/api.go", "package api\n", ParseAll)
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	assert.EqualError(t, p.parseJSONSchemaTypes(), "cannot find type definition: api.Missing")
+}
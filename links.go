@@ -0,0 +1,200 @@
+package swag
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const linksExtension = "x-links"
+
+// Link describes an OpenAPI 3 link object. Swagger 2.0 has no equivalent, so
+// links are emitted in the x-links extension of responses.
+type Link struct {
+	OperationID string            `json:"operationId"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+// @Link 201 getUser id=$response.body#/id,verbose=$request.query.verbose "Get the created user".
+var linkPattern = regexp.MustCompile(`^(\w+)[[:blank:]]+(\S+)(?:[[:blank:]]+([^"\s]\S*))?(?:[[:blank:]]+"([^"]*)")?$`)
+
+// ParseLinkComment parses comment for given `link` comment string.
+// The link is added to the response of given status code, which must be declared before.
+func (operation *Operation) ParseLinkComment(commentLine string) error {
+	matches := linkPattern.FindStringSubmatch(strings.TrimSpace(commentLine))
+	if len(matches) != 5 {
+		return fmt.Errorf("can not parse link comment \"%s\"", commentLine)
+	}
+
+	link := Link{
+		OperationID: matches[2],
+		Description: matches[4],
+	}
+
+	if matches[3] != "" {
+		link.Parameters = make(map[string]string)
+
+		for _, param := range strings.Split(matches[3], ",") {
+			keyVal := strings.SplitN(param, "=", 2)
+			if len(keyVal) != 2 || keyVal[0] == "" || keyVal[1] == "" {
+				return fmt.Errorf("link parameter should format: name=expression, got \"%s\"", param)
+			}
+
+			link.Parameters[keyVal[0]] = keyVal[1]
+		}
+	}
+
+	if strings.EqualFold(matches[1], defaultTag) {
+		if operation.Responses.Default == nil {
+			return fmt.Errorf("link to %s needs response %s to be declared before", link.OperationID, matches[1])
+		}
+
+		return addLink(operation.Responses.Default, link, matches[1])
+	}
+
+	code, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return fmt.Errorf("can not parse link comment \"%s\"", commentLine)
+	}
+
+	response, ok := operation.Responses.StatusCodeResponses[code]
+	if !ok {
+		return fmt.Errorf("link to %s needs response %s to be declared before", link.OperationID, matches[1])
+	}
+
+	err = addLink(&response, link, matches[1])
+	if err != nil {
+		return err
+	}
+
+	operation.Responses.StatusCodeResponses[code] = response
+
+	return nil
+}
+
+// addLink adds link to the links of response, keyed by the operation it targets, so a response links to
+// an operation once.
+func addLink(response *spec.Response, link Link, code string) error {
+	links, ok := response.Extensions[linksExtension].(map[string]Link)
+	if !ok {
+		links = make(map[string]Link)
+		response.AddExtension(linksExtension, links)
+	}
+
+	if _, ok := links[link.OperationID]; ok {
+		return fmt.Errorf("response %s already links to %s", code, link.OperationID)
+	}
+
+	links[link.OperationID] = link
+
+	return nil
+}
+
+// checkOperationLinks verifies that the operations and parameters referenced by links exist.
+func (parser *Parser) checkOperationLinks() error {
+	operations := make(map[string]*spec.Operation)
+
+	var paths []string
+
+	for path, item := range parser.swagger.Paths.Paths {
+		paths = append(paths, path)
+
+		for method := range allMethod {
+			op := refRouteMethodOp(&item, method)
+			if *op != nil && (*op).ID != "" {
+				operations[(*op).ID] = *op
+			}
+		}
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := parser.swagger.Paths.Paths[path]
+
+		for _, method := range sortedMethods() {
+			op := refRouteMethodOp(&item, method)
+			if *op == nil || (*op).Responses == nil {
+				continue
+			}
+
+			responses := (*op).Responses
+			if responses.Default != nil {
+				err := checkResponseLinks(operations, responses.Default, method, path)
+				if err != nil {
+					return err
+				}
+			}
+
+			codes := make([]int, 0, len(responses.StatusCodeResponses))
+			for code := range responses.StatusCodeResponses {
+				codes = append(codes, code)
+			}
+
+			sort.Ints(codes)
+
+			for _, code := range codes {
+				response := responses.StatusCodeResponses[code]
+
+				err := checkResponseLinks(operations, &response, method, path)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkResponseLinks(operations map[string]*spec.Operation, response *spec.Response, method, path string) error {
+	links, ok := response.Extensions[linksExtension].(map[string]Link)
+	if !ok {
+		return nil
+	}
+
+	operationIDs := make([]string, 0, len(links))
+	for operationID := range links {
+		operationIDs = append(operationIDs, operationID)
+	}
+
+	sort.Strings(operationIDs)
+
+	for _, operationID := range operationIDs {
+		link := links[operationID]
+
+		target, ok := operations[link.OperationID]
+		if !ok {
+			return fmt.Errorf("link in %s %s: operation with @id '%s' not found", method, path, link.OperationID)
+		}
+
+		for name := range link.Parameters {
+			if !hasLinkParameter(target, name) {
+				return fmt.Errorf("link in %s %s: operation '%s' has no parameter '%s'", method, path, link.OperationID, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasLinkParameter whether op has a parameter named name, optionally qualified by its location, eg: path.id.
+func hasLinkParameter(op *spec.Operation, name string) bool {
+	in := ""
+	if pos := strings.IndexByte(name, '.'); pos >= 0 {
+		in, name = name[:pos], name[pos+1:]
+	}
+
+	for _, param := range op.Parameters {
+		if param.Name == name && (in == "" || param.In == in) {
+			return true
+		}
+	}
+
+	return false
+}
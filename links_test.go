@@ -0,0 +1,142 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Success 201 {string} string "created"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Link 201 getUser id=$response.body#/id,verbose=$request.query.verbose "Get the created user"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Link 201 listUsers`, nil))
+
+	links := operation.Responses.StatusCodeResponses[201].Extensions[linksExtension]
+	assert.Equal(t, map[string]Link{
+		"getUser": {
+			OperationID: "getUser",
+			Parameters:  map[string]string{"id": "$response.body#/id", "verbose": "$request.query.verbose"},
+			Description: "Get the created user",
+		},
+		"listUsers": {OperationID: "listUsers"},
+	}, links)
+
+	b, _ := json.Marshal(operation.Responses.StatusCodeResponses[201])
+	assert.JSONEq(t, `{
+		"description": "created",
+		"schema": {"type": "string"},
+		"x-links": {
+			"getUser": {
+				"operationId": "getUser",
+				"parameters": {"id": "$response.body#/id", "verbose": "$request.query.verbose"},
+				"description": "Get the created user"
+			},
+			"listUsers": {"operationId": "listUsers"}
+		}
+	}`, string(b))
+}
+
+func TestParseLinkCommentErr(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.EqualError(t, operation.ParseComment(`/@Link 201 getUser id=$response.body#/id`, nil), "link to getUser needs response 201 to be declared before")
+	assert.Error(t, operation.ParseComment(`/@Link default getUser`, nil))
+	assert.Error(t, operation.ParseComment(`/@Link 201`, nil))
+
+	assert.NoError(t, operation.ParseComment(`/@Success 201`, nil))
+	assert.Error(t, operation.ParseComment(`/@Link 201 getUser id`, nil))
+
+	assert.NoError(t, operation.ParseComment(`/@Link 201 getUser id=$response.body#/id`, nil))
+	assert.EqualError(t, operation.ParseComment(`/@Link 201 getUser verbose=$request.query.verbose`, nil), "response 201 already links to getUser")
+}
+
+func TestParser_CheckOperationLinks(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @ID createUser
+// @Success 201 {string} string "created"
+// @Link 201 getUser path.id=$response.body#/id
+// @Router /users [post]
+func CreateUser(){
+}
+
+// @ID getUser
+// @Param id path int true "user id"
+// @Success 200
+// @Router /users/{id} [get]
+func GetUser(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.NoError(t, p.checkOperationLinks())
+
+	src = `
+package api
+
+// @ID createUser
+// @Success 201
+// @Link 201 getUser query.id=$response.body#/id
+// @Router /users [post]
+func CreateUser(){
+}
+
+// @ID getUser
+// @Param id path int true "user id"
+// @Success 200
+// @Router /users/{id} [get]
+func GetUser(){
+}
+`
+	p = New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.EqualError(t, p.checkOperationLinks(), "link in POST /users: operation 'getUser' has no parameter 'query.id'")
+
+	src = `
+package api
+
+// @Success 201
+// @Link 201 getUser id=$response.body#/id
+// @Router /users [post]
+func CreateUser(){
+}
+`
+	p = New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.EqualError(t, p.checkOperationLinks(), "link in POST /users: operation with @id 'getUser' not found")
+	src = `
+package api
+
+// @Success 200
+// @Success 201
+// @Link 201 zeta
+// @Link 200 beta
+// @Link 200 alpha
+// @Router /users [post]
+func CreateUser(){
+}
+
+// @Success 200
+// @Link 200 gamma
+// @Router /users [get]
+func GetUsers(){
+}
+`
+	for i := 0; i < 10; i++ {
+		p = New()
+		assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+		assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+		assert.EqualError(t, p.checkOperationLinks(), "link in GET /users: operation with @id 'gamma' not found")
+	}
+}
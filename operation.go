@@ -4,17 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/ast"
-	goparser "go/parser"
 	"go/token"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/go-openapi/spec"
-	"golang.org/x/tools/go/loader"
+	"github.com/swaggo/swag/internal/load"
 )
 
 // RouteProperties describes HTTP properties of a single router comment.
@@ -31,6 +31,8 @@ type Operation struct {
 	codeExampleFilesDir string
 	spec.Operation
 	RouterProperties []RouteProperties
+	Webhooks         []WebhookProperties
+	Callbacks        []CallbackProperties
 	State            string
 }
 
@@ -143,17 +145,43 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	case produceAttr:
 		return operation.ParseProduceComment(lineRemainder)
 	case paramAttr:
+		defer operation.parser.lockSchemas(astFile)()
+
 		return operation.ParseParamComment(lineRemainder, astFile)
 	case successAttr, failureAttr, responseAttr:
+		defer operation.parser.lockSchemas(astFile)()
+
 		return operation.ParseResponseComment(lineRemainder, astFile)
 	case headerAttr:
 		return operation.ParseResponseHeaderComment(lineRemainder, astFile)
+	case linkAttr:
+		return operation.ParseLinkComment(lineRemainder)
+	case streamAttr:
+		defer operation.parser.lockSchemas(astFile)()
+
+		return operation.ParseStreamComment(lineRemainder, astFile)
+	case visibilityAttr:
+		return operation.ParseVisibilityComment(lineRemainder)
+	case versionAttr:
+		return operation.ParseVersionComment(lineRemainder)
+	case sinceAttr:
+		return operation.ParseSinceComment(lineRemainder)
+	case changedAttr:
+		return operation.ParseChangedComment(lineRemainder)
 	case routerAttr:
 		return operation.ParseRouterComment(lineRemainder, false)
 	case deprecatedRouterAttr:
 		return operation.ParseRouterComment(lineRemainder, true)
+	case webhookAttr:
+		return operation.ParseWebhookComment(lineRemainder)
+	case callbackAttr:
+		return operation.ParseCallbackComment(lineRemainder)
 	case securityAttr:
 		return operation.ParseSecurityComment(lineRemainder)
+	case serverAttr, serversAttr:
+		return operation.ParseServerComment(attribute, lineRemainder)
+	case hostAttr:
+		return operation.ParseHostComment(lineRemainder)
 	case deprecatedAttr:
 		operation.Deprecate()
 	case xCodeSamplesAttr:
@@ -422,6 +450,7 @@ const (
 	readOnlyTag         = "readonly"
 	extensionsTag       = "extensions"
 	collectionFormatTag = "collectionFormat"
+	visibilityTag       = "visibility"
 )
 
 var regexAttributes = map[string]*regexp.Regexp{
@@ -449,11 +478,23 @@ var regexAttributes = map[string]*regexp.Regexp{
 	schemaExampleTag: regexp.MustCompile(`(?i)\s+schemaExample\(.*\)`),
 }
 
+// regexAttributeKeys the keys of regexAttributes in a stable order, the attributes of a param are set in.
+var regexAttributeKeys = func() []string {
+	keys := make([]string, 0, len(regexAttributes))
+	for key := range regexAttributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}()
+
 func (operation *Operation) parseParamAttribute(comment, objectType, schemaType, paramType string, param *spec.Parameter) error {
 	schemaType = TransToValidSchemeType(schemaType)
 
-	for attrKey, re := range regexAttributes {
-		attr, err := findAttr(re, comment)
+	for _, attrKey := range regexAttributeKeys {
+		attr, err := findAttr(regexAttributes[attrKey], comment)
 		if err != nil {
 			continue
 		}
@@ -772,45 +813,20 @@ func (operation *Operation) ParseSecurityComment(commentLine string) error {
 }
 
 // findTypeDef attempts to find the *ast.TypeSpec for a specific type given the
-// type's name and the package's import path.
-// TODO: improve finding external pkg.
-func findTypeDef(importPath, typeName string) (*ast.TypeSpec, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	conf := loader.Config{
-		ParserMode: goparser.SpuriousErrors,
-		Cwd:        cwd,
-	}
-
-	conf.Import(importPath)
-
-	lprog, err := conf.Load()
+// type's name and the package's import path, loaded with loader.
+func findTypeDef(loader *load.Loader, importPath, typeName string) (*ast.TypeSpec, error) {
+	pkg, err := loader.Package(importPath)
 	if err != nil {
 		return nil, err
 	}
 
-	// If the pkg is vendored, the actual pkg path is going to resemble
-	// something like "{importPath}/vendor/{importPath}"
-	for k := range lprog.AllPackages {
-		realPkgPath := k.Path()
-
-		if strings.Contains(realPkgPath, "vendor/"+importPath) {
-			importPath = realPkgPath
+	for _, path := range pkg.GoFiles {
+		_, astFile, err := parseGoFile(path, nil)
+		if err != nil {
+			return nil, err
 		}
-	}
-
-	pkgInfo := lprog.Package(importPath)
-
-	if pkgInfo == nil {
-		return nil, fmt.Errorf("package was nil")
-	}
 
-	// TODO: possibly cache pkgInfo since it's an expensive operation
-	for i := range pkgInfo.Files {
-		for _, astDeclaration := range pkgInfo.Files[i].Decls {
+		for _, astDeclaration := range astFile.Decls {
 			generalDeclaration, ok := astDeclaration.(*ast.GenDecl)
 			if ok && generalDeclaration.Tok == token.TYPE {
 				for _, astSpec := range generalDeclaration.Specs {
@@ -2262,7 +2262,7 @@ func TestParseIdComment(t *testing.T) {
 func TestFindTypeDefCoreLib(t *testing.T) {
 	t.Parallel()
 
-	s, err := findTypeDef("net/http", "Request")
+	s, err := findTypeDef(New().packages.loader, "net/http", "Request")
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 }
@@ -2270,7 +2270,7 @@ func TestFindTypeDefCoreLib(t *testing.T) {
 func TestFindTypeDefExternalPkg(t *testing.T) {
 	t.Parallel()
 
-	s, err := findTypeDef("github.com/KyleBanks/depth", "Tree")
+	s, err := findTypeDef(New().packages.loader, "github.com/go-openapi/spec", "Swagger")
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 }
@@ -2278,7 +2278,7 @@ func TestFindTypeDefExternalPkg(t *testing.T) {
 func TestFindTypeDefInvalidPkg(t *testing.T) {
 	t.Parallel()
 
-	s, err := findTypeDef("does-not-exist", "foo")
+	s, err := findTypeDef(New().packages.loader, "does-not-exist", "foo")
 	assert.Error(t, err)
 	assert.Nil(t, s)
 }
@@ -5,13 +5,13 @@ import (
 	"go/ast"
 	goparser "go/parser"
 	"go/token"
-	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strings"
 
-	"golang.org/x/tools/go/loader"
+	"github.com/swaggo/swag/internal/load"
+	"golang.org/x/tools/go/packages"
 )
 
 // PackagesDefinitions map[package import path]*PackageDefinitions.
@@ -21,6 +21,16 @@ type PackagesDefinitions struct {
 	uniqueDefinitions map[string]*TypeSpecDef
 	parseDependency   ParseFlag
 	debug             Debugger
+	jobs              int
+
+	// loader loads the external packages, the one of the parser
+	loader *load.Loader
+
+	// workspace the modules of the go.work file, whose packages are loaded even without parseDependency
+	workspace []load.Module
+
+	// readFile parses the files of the external packages, parseGoFile if nil
+	readFile func(path string, src interface{}, flag ParseFlag) (*token.FileSet, *ast.File, error)
 }
 
 // NewPackagesDefinitions create object PackagesDefinitions.
@@ -34,13 +44,22 @@ func NewPackagesDefinitions() *PackagesDefinitions {
 
 // ParseFile parse a source file.
 func (pkgDefs *PackagesDefinitions) ParseFile(packageDir, path string, src interface{}, flag ParseFlag) error {
+	fileSet, astFile, err := parseGoFile(path, src)
+	if err != nil {
+		return err
+	}
+	return pkgDefs.collectAstFile(fileSet, packageDir, path, astFile, flag)
+}
+
+// parseGoFile parses a source file with its comments.
+func parseGoFile(path string, src interface{}) (*token.FileSet, *ast.File, error) {
 	// positions are relative to FileSet
 	fileSet := token.NewFileSet()
 	astFile, err := goparser.ParseFile(fileSet, path, src, goparser.ParseComments)
 	if err != nil {
-		return fmt.Errorf("failed to parse file %s, error:%+v", path, err)
+		return nil, nil, fmt.Errorf("failed to parse file %s, error:%+v", path, err)
 	}
-	return pkgDefs.collectAstFile(fileSet, packageDir, path, astFile, flag)
+	return fileSet, astFile, nil
 }
 
 // collectAstFile collect ast.file.
@@ -87,6 +106,54 @@ func (pkgDefs *PackagesDefinitions) collectAstFile(fileSet *token.FileSet, packa
 	return nil
 }
 
+// fileInfo returns the info of the parsed file of given absolute path, nil if it isn't parsed.
+func (pkgDefs *PackagesDefinitions) fileInfo(path string) *AstFileInfo {
+	for _, info := range pkgDefs.files {
+		if info.Path == path {
+			return info
+		}
+	}
+
+	return nil
+}
+
+// removeFile removes the parsed file of given absolute path, returning its info if it was parsed.
+func (pkgDefs *PackagesDefinitions) removeFile(path string) *AstFileInfo {
+	for astFile, info := range pkgDefs.files {
+		if info.Path != path {
+			continue
+		}
+
+		delete(pkgDefs.files, astFile)
+
+		if pkg, ok := pkgDefs.packages[info.PackagePath]; ok {
+			delete(pkg.Files, path)
+		}
+
+		return info
+	}
+
+	return nil
+}
+
+// resetTypes forgets the types and const variables collected by ParseTypes, keeping the parsed files.
+// Packages without parsed files, loaded when a type was looked up, are loaded again when needed.
+func (pkgDefs *PackagesDefinitions) resetTypes() {
+	for pkgPath, pkg := range pkgDefs.packages {
+		if len(pkg.Files) == 0 {
+			delete(pkgDefs.packages, pkgPath)
+
+			continue
+		}
+
+		pkg.TypeDefinitions = make(map[string]*TypeSpecDef)
+		pkg.ConstTable = make(map[string]*ConstVariable)
+		pkg.OrderedConst = nil
+	}
+
+	pkgDefs.uniqueDefinitions = make(map[string]*TypeSpecDef)
+}
+
 // RangeFiles for range the collection of ast.File in alphabetic order.
 func (pkgDefs *PackagesDefinitions) RangeFiles(handle func(info *AstFileInfo) error) error {
 	sortedFiles := make([]*AstFileInfo, 0, len(pkgDefs.files))
@@ -117,9 +184,26 @@ func (pkgDefs *PackagesDefinitions) RangeFiles(handle func(info *AstFileInfo) er
 // @Return parsed definitions.
 func (pkgDefs *PackagesDefinitions) ParseTypes() (map[*TypeSpecDef]*Schema, error) {
 	parsedSchemas := make(map[*TypeSpecDef]*Schema)
-	for astFile, info := range pkgDefs.files {
-		pkgDefs.parseTypesFromFile(astFile, info.PackagePath, parsedSchemas)
-		pkgDefs.parseFunctionScopedTypesFromFile(astFile, info.PackagePath, parsedSchemas)
+
+	// in the order of the file paths, so the names of not unique types and the order of enums are stable
+	sortedFiles := make([]*AstFileInfo, 0, len(pkgDefs.files))
+	for _, info := range pkgDefs.files {
+		sortedFiles = append(sortedFiles, info)
+	}
+
+	sort.Slice(sortedFiles, func(i, j int) bool {
+		return sortedFiles[i].Path < sortedFiles[j].Path
+	})
+
+	// the declarations are collected concurrently, then added one file after another
+	types := make([]*fileTypes, len(sortedFiles))
+	forEachJob(pkgDefs.jobs, len(sortedFiles), func(i int) {
+		types[i] = collectFileTypes(sortedFiles[i].File, sortedFiles[i].PackagePath)
+	})
+
+	for _, fileTypes := range types {
+		pkgDefs.addFileTypes(fileTypes, parsedSchemas)
+		pkgDefs.addFunctionScopedTypes(fileTypes, parsedSchemas)
 	}
 	pkgDefs.removeAllNotUniqueTypes()
 	pkgDefs.evaluateAllConstVariables()
@@ -128,154 +212,216 @@ func (pkgDefs *PackagesDefinitions) ParseTypes() (map[*TypeSpecDef]*Schema, erro
 }
 
 func (pkgDefs *PackagesDefinitions) parseTypesFromFile(astFile *ast.File, packagePath string, parsedSchemas map[*TypeSpecDef]*Schema) {
-	for _, astDeclaration := range astFile.Decls {
-		generalDeclaration, ok := astDeclaration.(*ast.GenDecl)
-		if !ok {
-			continue
-		}
-		if generalDeclaration.Tok == token.TYPE {
-			for _, astSpec := range generalDeclaration.Specs {
-				if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
-					typeSpecDef := &TypeSpecDef{
-						PkgPath:  packagePath,
-						File:     astFile,
-						TypeSpec: typeSpec,
-					}
+	pkgDefs.addFileTypes(collectFileTypes(astFile, packagePath), parsedSchemas)
+}
 
-					if idt, ok := typeSpec.Type.(*ast.Ident); ok && IsGolangPrimitiveType(idt.Name) && parsedSchemas != nil {
-						parsedSchemas[typeSpecDef] = &Schema{
-							PkgPath: typeSpecDef.PkgPath,
-							Name:    astFile.Name.Name,
-							Schema:  TransToValidPrimitiveSchema(idt.Name),
-						}
-					}
+func (pkgDefs *PackagesDefinitions) parseFunctionScopedTypesFromFile(astFile *ast.File, packagePath string, parsedSchemas map[*TypeSpecDef]*Schema) {
+	pkgDefs.addFunctionScopedTypes(collectFileTypes(astFile, packagePath), parsedSchemas)
+}
 
-					if pkgDefs.uniqueDefinitions == nil {
-						pkgDefs.uniqueDefinitions = make(map[string]*TypeSpecDef)
-					}
+// fileTypes the type and const declarations of a file, collected concurrently by ParseTypes
+// before being added to the definitions one file after another.
+type fileTypes struct {
+	file        *ast.File
+	packagePath string
 
-					fullName := typeSpecDef.TypeName()
-
-					anotherTypeDef, ok := pkgDefs.uniqueDefinitions[fullName]
-					if ok {
-						if anotherTypeDef == nil {
-							typeSpecDef.NotUnique = true
-							fullName = typeSpecDef.TypeName()
-							pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-						} else if typeSpecDef.PkgPath != anotherTypeDef.PkgPath {
-							pkgDefs.uniqueDefinitions[fullName] = nil
-							anotherTypeDef.NotUnique = true
-							pkgDefs.uniqueDefinitions[anotherTypeDef.TypeName()] = anotherTypeDef
-							anotherTypeDef.SetSchemaName()
-
-							typeSpecDef.NotUnique = true
-							fullName = typeSpecDef.TypeName()
-							pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-						}
-					} else {
-						pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-					}
+	// types the types declared at the top level of the file
+	types []*TypeSpecDef
+
+	// consts the const declarations of the file
+	consts []*ast.GenDecl
+
+	// functionScopedTypes the types declared in the functions of the file, by function
+	functionScopedTypes [][]*TypeSpecDef
+}
 
-					typeSpecDef.SetSchemaName()
+// collectFileTypes returns the type and const declarations of astFile, without changing the definitions.
+func collectFileTypes(astFile *ast.File, packagePath string) *fileTypes {
+	types := &fileTypes{
+		file:        astFile,
+		packagePath: packagePath,
+	}
 
-					if pkgDefs.packages[typeSpecDef.PkgPath] == nil {
-						pkgDefs.packages[typeSpecDef.PkgPath] = NewPackageDefinitions(astFile.Name.Name, typeSpecDef.PkgPath).AddTypeSpec(typeSpecDef.Name(), typeSpecDef)
-					} else if _, ok = pkgDefs.packages[typeSpecDef.PkgPath].TypeDefinitions[typeSpecDef.Name()]; !ok {
-						pkgDefs.packages[typeSpecDef.PkgPath].AddTypeSpec(typeSpecDef.Name(), typeSpecDef)
+	for _, astDeclaration := range astFile.Decls {
+		switch declaration := astDeclaration.(type) {
+		case *ast.GenDecl:
+			if declaration.Tok == token.TYPE {
+				for _, astSpec := range declaration.Specs {
+					if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
+						types.types = append(types.types, &TypeSpecDef{
+							PkgPath:  packagePath,
+							File:     astFile,
+							TypeSpec: typeSpec,
+						})
 					}
 				}
+			} else if declaration.Tok == token.CONST {
+				types.consts = append(types.consts, declaration)
+			}
+		case *ast.FuncDecl:
+			if declaration.Body == nil {
+				continue
 			}
-		} else if generalDeclaration.Tok == token.CONST {
-			// collect consts
-			pkgDefs.collectConstVariables(astFile, packagePath, generalDeclaration)
-		}
-	}
-}
 
-func (pkgDefs *PackagesDefinitions) parseFunctionScopedTypesFromFile(astFile *ast.File, packagePath string, parsedSchemas map[*TypeSpecDef]*Schema) {
-	for _, astDeclaration := range astFile.Decls {
-		funcDeclaration, ok := astDeclaration.(*ast.FuncDecl)
-		if ok && funcDeclaration.Body != nil {
-			functionScopedTypes := make(map[string]*TypeSpecDef)
-			for _, stmt := range funcDeclaration.Body.List {
+			var functionTypes []*TypeSpecDef
+
+			for _, stmt := range declaration.Body.List {
 				if declStmt, ok := (stmt).(*ast.DeclStmt); ok {
 					if genDecl, ok := (declStmt.Decl).(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
 						for _, astSpec := range genDecl.Specs {
 							if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
-								typeSpecDef := &TypeSpecDef{
+								functionTypes = append(functionTypes, &TypeSpecDef{
 									PkgPath:    packagePath,
 									File:       astFile,
 									TypeSpec:   typeSpec,
 									ParentSpec: astDeclaration,
-								}
-
-								if idt, ok := typeSpec.Type.(*ast.Ident); ok && IsGolangPrimitiveType(idt.Name) && parsedSchemas != nil {
-									parsedSchemas[typeSpecDef] = &Schema{
-										PkgPath: typeSpecDef.PkgPath,
-										Name:    astFile.Name.Name,
-										Schema:  TransToValidPrimitiveSchema(idt.Name),
-									}
-								}
-
-								fullName := typeSpecDef.TypeName()
-								if structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType); ok {
-									for _, field := range structType.Fields.List {
-										var idt *ast.Ident
-										var ok bool
-										switch field.Type.(type) {
-										case *ast.Ident:
-											idt, ok = field.Type.(*ast.Ident)
-										case *ast.StarExpr:
-											idt, ok = field.Type.(*ast.StarExpr).X.(*ast.Ident)
-										case *ast.ArrayType:
-											idt, ok = field.Type.(*ast.ArrayType).Elt.(*ast.Ident)
-										}
-										if ok && !IsGolangPrimitiveType(idt.Name) {
-											if functype, ok := functionScopedTypes[idt.Name]; ok {
-												idt.Name = functype.TypeName()
-											}
-										}
-									}
-								}
-
-								if pkgDefs.uniqueDefinitions == nil {
-									pkgDefs.uniqueDefinitions = make(map[string]*TypeSpecDef)
-								}
-
-								anotherTypeDef, ok := pkgDefs.uniqueDefinitions[fullName]
-								if ok {
-									if anotherTypeDef == nil {
-										typeSpecDef.NotUnique = true
-										fullName = typeSpecDef.TypeName()
-										pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-									} else if typeSpecDef.PkgPath != anotherTypeDef.PkgPath {
-										pkgDefs.uniqueDefinitions[fullName] = nil
-										anotherTypeDef.NotUnique = true
-										pkgDefs.uniqueDefinitions[anotherTypeDef.TypeName()] = anotherTypeDef
-										anotherTypeDef.SetSchemaName()
-
-										typeSpecDef.NotUnique = true
-										fullName = typeSpecDef.TypeName()
-										pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-									}
-								} else {
-									pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
-									functionScopedTypes[typeSpec.Name.Name] = typeSpecDef
-								}
-
-								typeSpecDef.SetSchemaName()
-
-								if pkgDefs.packages[typeSpecDef.PkgPath] == nil {
-									pkgDefs.packages[typeSpecDef.PkgPath] = NewPackageDefinitions(astFile.Name.Name, typeSpecDef.PkgPath).AddTypeSpec(fullName, typeSpecDef)
-								} else if _, ok = pkgDefs.packages[typeSpecDef.PkgPath].TypeDefinitions[fullName]; !ok {
-									pkgDefs.packages[typeSpecDef.PkgPath].AddTypeSpec(fullName, typeSpecDef)
-								}
+								})
 							}
 						}
+					}
+				}
+			}
+
+			if len(functionTypes) > 0 {
+				types.functionScopedTypes = append(types.functionScopedTypes, functionTypes)
+			}
+		}
+	}
+
+	return types
+}
+
+// addFileTypes adds the types and consts declared at the top level of a file to the definitions.
+func (pkgDefs *PackagesDefinitions) addFileTypes(types *fileTypes, parsedSchemas map[*TypeSpecDef]*Schema) {
+	astFile := types.file
+
+	for _, typeSpecDef := range types.types {
+		if schema := primitiveTypeSchema(typeSpecDef); schema != nil && parsedSchemas != nil {
+			parsedSchemas[typeSpecDef] = schema
+		}
+
+		if pkgDefs.uniqueDefinitions == nil {
+			pkgDefs.uniqueDefinitions = make(map[string]*TypeSpecDef)
+		}
+
+		fullName := typeSpecDef.TypeName()
+
+		anotherTypeDef, ok := pkgDefs.uniqueDefinitions[fullName]
+		if ok {
+			if anotherTypeDef == nil {
+				typeSpecDef.NotUnique = true
+				fullName = typeSpecDef.TypeName()
+				pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+			} else if typeSpecDef.PkgPath != anotherTypeDef.PkgPath {
+				pkgDefs.uniqueDefinitions[fullName] = nil
+				anotherTypeDef.NotUnique = true
+				pkgDefs.uniqueDefinitions[anotherTypeDef.TypeName()] = anotherTypeDef
+				anotherTypeDef.SetSchemaName()
+
+				typeSpecDef.NotUnique = true
+				fullName = typeSpecDef.TypeName()
+				pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+			}
+		} else {
+			pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+		}
+
+		typeSpecDef.SetSchemaName()
+
+		if pkgDefs.packages[typeSpecDef.PkgPath] == nil {
+			pkgDefs.packages[typeSpecDef.PkgPath] = NewPackageDefinitions(astFile.Name.Name, typeSpecDef.PkgPath).AddTypeSpec(typeSpecDef.Name(), typeSpecDef)
+		} else if _, ok = pkgDefs.packages[typeSpecDef.PkgPath].TypeDefinitions[typeSpecDef.Name()]; !ok {
+			pkgDefs.packages[typeSpecDef.PkgPath].AddTypeSpec(typeSpecDef.Name(), typeSpecDef)
+		}
+	}
+
+	for _, generalDeclaration := range types.consts {
+		// collect consts
+		pkgDefs.collectConstVariables(astFile, types.packagePath, generalDeclaration)
+	}
+}
+
+// primitiveTypeSchema returns the schema of typeSpecDef if it is defined by a primitive type, nil otherwise.
+func primitiveTypeSchema(typeSpecDef *TypeSpecDef) *Schema {
+	idt, ok := typeSpecDef.TypeSpec.Type.(*ast.Ident)
+	if !ok || !IsGolangPrimitiveType(idt.Name) {
+		return nil
+	}
+
+	return &Schema{
+		PkgPath: typeSpecDef.PkgPath,
+		Name:    typeSpecDef.File.Name.Name,
+		Schema:  TransToValidPrimitiveSchema(idt.Name),
+	}
+}
+
+// addFunctionScopedTypes adds the types declared in the functions of a file to the definitions.
+func (pkgDefs *PackagesDefinitions) addFunctionScopedTypes(types *fileTypes, parsedSchemas map[*TypeSpecDef]*Schema) {
+	astFile := types.file
+
+	for _, functionTypes := range types.functionScopedTypes {
+		functionScopedTypes := make(map[string]*TypeSpecDef)
 
+		for _, typeSpecDef := range functionTypes {
+			typeSpec := typeSpecDef.TypeSpec
+
+			if schema := primitiveTypeSchema(typeSpecDef); schema != nil && parsedSchemas != nil {
+				parsedSchemas[typeSpecDef] = schema
+			}
+
+			fullName := typeSpecDef.TypeName()
+			if structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType); ok {
+				for _, field := range structType.Fields.List {
+					var idt *ast.Ident
+					var ok bool
+					switch field.Type.(type) {
+					case *ast.Ident:
+						idt, ok = field.Type.(*ast.Ident)
+					case *ast.StarExpr:
+						idt, ok = field.Type.(*ast.StarExpr).X.(*ast.Ident)
+					case *ast.ArrayType:
+						idt, ok = field.Type.(*ast.ArrayType).Elt.(*ast.Ident)
+					}
+					if ok && !IsGolangPrimitiveType(idt.Name) {
+						if functype, ok := functionScopedTypes[idt.Name]; ok {
+							idt.Name = functype.TypeName()
+						}
 					}
 				}
 			}
+
+			if pkgDefs.uniqueDefinitions == nil {
+				pkgDefs.uniqueDefinitions = make(map[string]*TypeSpecDef)
+			}
+
+			anotherTypeDef, ok := pkgDefs.uniqueDefinitions[fullName]
+			if ok {
+				if anotherTypeDef == nil {
+					typeSpecDef.NotUnique = true
+					fullName = typeSpecDef.TypeName()
+					pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+				} else if typeSpecDef.PkgPath != anotherTypeDef.PkgPath {
+					pkgDefs.uniqueDefinitions[fullName] = nil
+					anotherTypeDef.NotUnique = true
+					pkgDefs.uniqueDefinitions[anotherTypeDef.TypeName()] = anotherTypeDef
+					anotherTypeDef.SetSchemaName()
+
+					typeSpecDef.NotUnique = true
+					fullName = typeSpecDef.TypeName()
+					pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+				}
+			} else {
+				pkgDefs.uniqueDefinitions[fullName] = typeSpecDef
+				functionScopedTypes[typeSpec.Name.Name] = typeSpecDef
+			}
+
+			typeSpecDef.SetSchemaName()
+
+			if pkgDefs.packages[typeSpecDef.PkgPath] == nil {
+				pkgDefs.packages[typeSpecDef.PkgPath] = NewPackageDefinitions(astFile.Name.Name, typeSpecDef.PkgPath).AddTypeSpec(fullName, typeSpecDef)
+			} else if _, ok = pkgDefs.packages[typeSpecDef.PkgPath].TypeDefinitions[fullName]; !ok {
+				pkgDefs.packages[typeSpecDef.PkgPath].AddTypeSpec(fullName, typeSpecDef)
+			}
 		}
 	}
 }
@@ -353,13 +499,15 @@ func (pkgDefs *PackagesDefinitions) EvaluateConstValueByName(file *ast.File, pkg
 			}
 		}
 	}
-	if pkgDefs.parseDependency > 0 {
-		for _, pkgPath := range externalPkgPaths {
-			if err := pkgDefs.loadExternalPackage(pkgPath); err == nil {
-				if pkg, ok := pkgDefs.packages[pkgPath]; ok {
-					if cv, ok := pkg.ConstTable[constVariableName]; ok {
-						return pkgDefs.EvaluateConstValue(pkg, cv, recursiveStack)
-					}
+	for _, pkgPath := range externalPkgPaths {
+		if !pkgDefs.loadsPackage(pkgPath) {
+			continue
+		}
+
+		if err := pkgDefs.loadExternalPackage(pkgPath); err == nil {
+			if pkg, ok := pkgDefs.packages[pkgPath]; ok {
+				if cv, ok := pkg.ConstTable[constVariableName]; ok {
+					return pkgDefs.EvaluateConstValue(pkg, cv, recursiveStack)
 				}
 			}
 		}
@@ -437,31 +585,63 @@ func (pkgDefs *PackagesDefinitions) findTypeSpec(pkgPath string, typeName string
 }
 
 func (pkgDefs *PackagesDefinitions) loadExternalPackage(importPath string) error {
-	cwd, err := os.Getwd()
+	if pkgDefs.loader == nil {
+		return fmt.Errorf("no loader for package %s", importPath)
+	}
+
+	pkg, err := pkgDefs.loader.Package(importPath)
 	if err != nil {
 		return err
 	}
 
-	conf := loader.Config{
-		ParserMode: goparser.ParseComments,
-		Cwd:        cwd,
+	readFile := pkgDefs.readFile
+	if readFile == nil {
+		readFile = func(path string, src interface{}, _ ParseFlag) (*token.FileSet, *ast.File, error) {
+			return parseGoFile(path, src)
+		}
 	}
 
-	conf.Import(importPath)
+	packages.Visit([]*packages.Package{pkg}, func(pkg *packages.Package) bool {
+		if err != nil {
+			return false
+		}
 
-	loaderProgram, err := conf.Load()
-	if err != nil {
-		return err
-	}
+		pkgPath := strings.TrimPrefix(pkg.PkgPath, "vendor/")
+		if _, ok := pkgDefs.packages[pkgPath]; ok || !pkgDefs.loadsPackage(pkgPath) {
+			return false
+		}
+
+		for _, path := range pkg.GoFiles {
+			var astFile *ast.File
+
+			_, astFile, err = readFile(path, nil, ParseModels)
+			if err != nil {
+				return false
+			}
 
-	for _, info := range loaderProgram.AllPackages {
-		pkgPath := strings.TrimPrefix(info.Pkg.Path(), "vendor/")
-		for _, astFile := range info.Files {
 			pkgDefs.parseTypesFromFile(astFile, pkgPath, nil)
 		}
+
+		return true
+	}, nil)
+
+	return err
+}
+
+// loadsPackage reports whether the external package of pkgPath is loaded: any with parseDependency,
+// only the ones of the workspace modules otherwise.
+func (pkgDefs *PackagesDefinitions) loadsPackage(pkgPath string) bool {
+	if pkgDefs.parseDependency > 0 {
+		return true
+	}
+
+	for _, module := range pkgDefs.workspace {
+		if module.Contains(pkgPath) {
+			return true
+		}
 	}
 
-	return nil
+	return false
 }
 
 // findPackagePathFromImports finds out the package path of a package via ranging imports of an ast.File
@@ -535,13 +715,15 @@ func (pkgDefs *PackagesDefinitions) findPackagePathFromImports(pkg string, file
 }
 
 func (pkgDefs *PackagesDefinitions) findTypeSpecFromPackagePaths(matchedPkgPaths, externalPkgPaths []string, name string) (typeDef *TypeSpecDef) {
-	if pkgDefs.parseDependency > 0 {
-		for _, pkgPath := range externalPkgPaths {
-			if err := pkgDefs.loadExternalPackage(pkgPath); err == nil {
-				typeDef = pkgDefs.findTypeSpec(pkgPath, name)
-				if typeDef != nil {
-					return typeDef
-				}
+	for _, pkgPath := range externalPkgPaths {
+		if !pkgDefs.loadsPackage(pkgPath) {
+			continue
+		}
+
+		if err := pkgDefs.loadExternalPackage(pkgPath); err == nil {
+			typeDef = pkgDefs.findTypeSpec(pkgPath, name)
+			if typeDef != nil {
+				return typeDef
 			}
 		}
 	}
@@ -599,7 +781,15 @@ func (pkgDefs *PackagesDefinitions) FindTypeSpec(typeName string, file *ast.File
 	}
 
 	// in case that comment //@name renamed the type with a name without a dot
-	for k, v := range pkgDefs.uniqueDefinitions {
+	keys := make([]string, 0, len(pkgDefs.uniqueDefinitions))
+	for k := range pkgDefs.uniqueDefinitions {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := pkgDefs.uniqueDefinitions[k]
 		if v == nil {
 			pkgDefs.debug.Printf("%s TypeSpecDef is nil", k)
 			continue
@@ -0,0 +1,350 @@
+package swag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// parseCacheFormat the version of the format of the cache entries, changed when stripSource changes
+	parseCacheFormat = 3
+
+	// parseCacheMaxAge the time after which the cache entries which weren't used are removed
+	parseCacheMaxAge = 5 * 24 * time.Hour
+
+	// parseCacheTrimInterval the minimum time between two removals of the unused cache entries
+	parseCacheTrimInterval = 24 * time.Hour
+
+	// parseCacheTouchInterval the minimum time between two updates of the modification time of a used entry
+	parseCacheTouchInterval = time.Hour
+
+	// parseCacheTrimFile the file of the cache directory holding the time of the last removal of unused entries
+	parseCacheTrimFile = "trim.txt"
+)
+
+// parseCache stores the sources of the parsed files stripped of what swag doesn't read, see stripSource, so they
+// are faster to parse on the next runs. It doesn't store the parsed declarations: the files are still parsed on
+// every run, from their stripped sources, except the files of which only the models are parsed and which declare
+// neither types nor constants, which are stored as empty entries and not parsed at all.
+// An entry is keyed by everything it is derived from: the swag version, the parse options and the content of the
+// file, or for the files of the module cache and of GOROOT, which aren't read to compute their key, their path,
+// size and modification time, and the Go version for GOROOT.
+// Like the go build cache, the entries which weren't used for parseCacheMaxAge are removed.
+type parseCache struct {
+	dir           string
+	immutableDirs []string
+	goroot        string
+	goVersion     string
+	debug         Debugger
+
+	trimOnce sync.Once
+}
+
+func newParseCache(dir string, debug Debugger) *parseCache {
+	modCache := os.Getenv("GOMODCACHE")
+	if modCache == "" {
+		modCache = filepath.Join(strings.Split(build.Default.GOPATH, string(filepath.ListSeparator))[0], "pkg", "mod")
+	}
+
+	cache := &parseCache{
+		dir:       dir,
+		goVersion: runtime.Version(),
+		debug:     debug,
+	}
+
+	if goroot := runtime.GOROOT(); goroot != "" {
+		cache.goroot = filepath.Clean(goroot) + string(filepath.Separator)
+		cache.immutableDirs = append(cache.immutableDirs, cache.goroot)
+	}
+
+	if modCache != "" {
+		cache.immutableDirs = append(cache.immutableDirs, filepath.Clean(modCache)+string(filepath.Separator))
+	}
+
+	return cache
+}
+
+// source returns the stripped source of the file of path parsed with flag, from the cache if it is stored there,
+// or nil if the file declares nothing swag reads.
+func (cache *parseCache) source(path string, parseFuncBody bool, flag ParseFlag) ([]byte, error) {
+	cache.trimOnce.Do(cache.trim)
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	modelsOnly := flag&ParseOperations == ParseNone
+
+	var src []byte
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\x00%d\x00%t\x00%t\x00", Version, parseCacheFormat, parseFuncBody, modelsOnly)
+
+	if cache.immutable(absPath) {
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _ = fmt.Fprintf(hash, "%s\x00%d\x00%d\x00", absPath, info.Size(), info.ModTime().UnixNano())
+
+		if strings.HasPrefix(absPath, cache.goroot) {
+			_, _ = hash.Write([]byte(cache.goVersion))
+		}
+	} else {
+		src, err = os.ReadFile(absPath)
+		if err != nil {
+			return nil, err
+		}
+
+		_, _ = hash.Write(src)
+	}
+
+	key := hex.EncodeToString(hash.Sum(nil))
+	file := filepath.Join(cache.dir, key[:2], key)
+
+	cached, err := os.ReadFile(file)
+	if err == nil {
+		cache.touch(file)
+
+		if len(cached) == 0 {
+			return nil, nil
+		}
+
+		return cached, nil
+	}
+
+	if src == nil {
+		src, err = os.ReadFile(absPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stripped, err := stripSource(path, src, parseFuncBody, modelsOnly)
+	if err != nil {
+		// let the parser report the syntax errors
+		return src, nil
+	}
+
+	if err := writeCacheFile(file, stripped); err != nil {
+		cache.debug.Printf("warning: failed to write parse cache of %s: %s", path, err)
+	}
+
+	return stripped, nil
+}
+
+// immutable reports whether the file of path is in the module cache or in GOROOT.
+func (cache *parseCache) immutable(path string) bool {
+	for _, dir := range cache.immutableDirs {
+		if strings.HasPrefix(path, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// touch updates the modification time of the cache entry file when it is used, at most every
+// parseCacheTouchInterval, the time trim removes the unused entries from.
+func (cache *parseCache) touch(file string) {
+	info, err := os.Stat(file)
+	if err != nil || time.Since(info.ModTime()) < parseCacheTouchInterval {
+		return
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(file, now, now)
+}
+
+// trim removes the cache entries which weren't used for parseCacheMaxAge, at most every parseCacheTrimInterval.
+func (cache *parseCache) trim() {
+	trimFile := filepath.Join(cache.dir, parseCacheTrimFile)
+
+	if data, err := os.ReadFile(trimFile); err == nil {
+		if last, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil &&
+			time.Since(time.Unix(last, 0)) < parseCacheTrimInterval {
+			return
+		}
+	}
+
+	now := time.Now()
+
+	err := filepath.Walk(cache.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if !info.IsDir() && path != trimFile && now.Sub(info.ModTime()) > parseCacheMaxAge {
+			return os.Remove(path)
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = writeCacheFile(trimFile, []byte(strconv.FormatInt(now.Unix(), 10)))
+	}
+
+	if err != nil {
+		cache.debug.Printf("warning: failed to trim parse cache %s: %s", cache.dir, err)
+	}
+}
+
+// writeCacheFile writes a cache file through a temporary file, so concurrent runs never read a partial one.
+func writeCacheFile(file string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// stripSource removes the code of the function bodies which don't declare types from src, unless parseFuncBody
+// is set. With modelsOnly, when only the models of the file are parsed, it removes the functions which don't
+// declare types and the variables too, and returns nil if the file declares neither types nor constants.
+// The comments and the line breaks of the removed code are kept, so the comments and the positions of the rest
+// of the file are the same.
+func stripSource(path string, src []byte, parseFuncBody, modelsOnly bool) ([]byte, error) {
+	if parseFuncBody && !modelsOnly {
+		return src, nil
+	}
+
+	fileSet := token.NewFileSet()
+
+	astFile, err := goparser.ParseFile(fileSet, path, src, goparser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := func(pos token.Pos) int {
+		return fileSet.Position(pos).Offset
+	}
+
+	// the ranges of the code to remove, in the order of the file
+	var removed [][2]int
+
+	// declares whether the file declares types or constants
+	declares := false
+
+	for _, decl := range astFile.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			switch {
+			case decl.Body != nil && declaresTypes(decl.Body):
+				declares = true
+			case modelsOnly:
+				removed = append(removed, [2]int{offset(decl.Pos()), offset(decl.End())})
+			case decl.Body != nil:
+				removed = append(removed, [2]int{offset(decl.Body.Lbrace) + 1, offset(decl.Body.Rbrace)})
+			}
+		case *ast.GenDecl:
+			switch decl.Tok {
+			case token.TYPE, token.CONST:
+				declares = true
+			case token.VAR:
+				if modelsOnly {
+					removed = append(removed, [2]int{offset(decl.Pos()), offset(decl.End())})
+				}
+			}
+		}
+	}
+
+	if modelsOnly && !declares {
+		return nil, nil
+	}
+
+	if len(removed) == 0 {
+		return src, nil
+	}
+
+	comments := make([][2]int, 0, len(astFile.Comments))
+	for _, group := range astFile.Comments {
+		for _, comment := range group.List {
+			comments = append(comments, [2]int{offset(comment.Pos()), offset(comment.End())})
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i][0] < comments[j][0]
+	})
+
+	stripped := make([]byte, 0, len(src))
+	start, c := 0, 0
+
+	for _, code := range removed {
+		stripped = append(stripped, src[start:code[0]]...)
+
+		for i := code[0]; i < code[1]; {
+			for c < len(comments) && comments[c][1] <= i {
+				c++
+			}
+
+			if c < len(comments) && comments[c][0] <= i {
+				stripped = append(stripped, src[i:comments[c][1]]...)
+				i = comments[c][1]
+
+				continue
+			}
+
+			if src[i] == '\n' {
+				stripped = append(stripped, '\n')
+			}
+
+			i++
+		}
+
+		start = code[1]
+	}
+
+	return append(stripped, src[start:]...), nil
+}
+
+// declaresTypes reports whether a function body declares types, parsed as function scoped types.
+func declaresTypes(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		if declStmt, ok := stmt.(*ast.DeclStmt); ok {
+			if genDecl, ok := declStmt.Decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,233 @@
+package swag
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripSource(t *testing.T) {
+	t.Parallel()
+
+	src := `package api
+
+// GetUser godoc
+// @Router /users [get]
+func GetUser() {
+	user := load() // @Summary kept
+	/* block */ save(user)
+}
+
+func Scoped() {
+	type Request struct {
+		Name string
+	}
+}
+
+func Empty() {}
+`
+	stripped, err := stripSource("api.go", []byte(src), false, false)
+	require.NoError(t, err)
+	assert.Equal(t, `package api
+
+// GetUser godoc
+// @Router /users [get]
+func GetUser() {
+// @Summary kept
+/* block */
+}
+
+func Scoped() {
+	type Request struct {
+		Name string
+	}
+}
+
+func Empty() {}
+`, string(stripped))
+	assert.Equal(t, strings.Count(src, "\n"), strings.Count(string(stripped), "\n"))
+
+	stripped, err = stripSource("api.go", []byte(src), true, false)
+	require.NoError(t, err)
+	assert.Equal(t, src, string(stripped))
+
+	// only the models of the file are parsed
+	stripped, err = stripSource("api.go", []byte(src+"\nvar users = load()\n\nconst Admin = \"admin\"\n"), true, true)
+	require.NoError(t, err)
+	assert.Equal(t, `package api
+
+// GetUser godoc
+// @Router /users [get]
+
+// @Summary kept
+/* block */
+
+
+func Scoped() {
+	type Request struct {
+		Name string
+	}
+}
+
+
+
+
+
+const Admin = "admin"
+`, string(stripped))
+	assert.Equal(t, strings.Count(src, "\n")+4, strings.Count(string(stripped), "\n"))
+
+	// nothing is parsed from a file declaring neither types nor constants
+	stripped, err = stripSource("api.go", []byte("package api\n\nimport \"fmt\"\n\nvar v = fmt.Sprint()\n\nfunc F() {}\n"), false, true)
+	require.NoError(t, err)
+	assert.Nil(t, stripped)
+
+	_, err = stripSource("api.go", []byte("package api\nfunc {"), false, false)
+	assert.Error(t, err)
+}
+
+func TestParseCache_Source(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "src")
+	modDir := filepath.Join(dir, "mod")
+
+	require.NoError(t, os.MkdirAll(srcDir, os.ModePerm))
+	require.NoError(t, os.MkdirAll(modDir, os.ModePerm))
+
+	cache := newParseCache(filepath.Join(dir, "cache"), log.New(os.Stdout, "", 0))
+	cache.immutableDirs = []string{modDir + string(filepath.Separator)}
+
+	file := filepath.Join(srcDir, "api.go")
+	require.NoError(t, os.WriteFile(file, []byte("package api\n\nfunc F() {\n\tf()\n}\n"), 0644))
+
+	src, err := cache.source(file, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, "package api\n\nfunc F() {\n\n}\n", string(src))
+
+	cached, err := filepath.Glob(filepath.Join(dir, "cache", "*", "*"))
+	require.NoError(t, err)
+	assert.Len(t, cached, 1)
+
+	require.NoError(t, os.WriteFile(file, []byte("package api\n\nfunc G() {\n\tg()\n}\n"), 0644))
+
+	src, err = cache.source(file, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, "package api\n\nfunc G() {\n\n}\n", string(src))
+
+	// the files of the module cache are keyed by path, size and modification time
+	modFile := filepath.Join(modDir, "dep.go")
+	require.NoError(t, os.WriteFile(modFile, []byte("package dep\n\nfunc F() {\n\tf()\n}\n"), 0644))
+
+	src, err = cache.source(modFile, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, "package dep\n\nfunc F() {\n\n}\n", string(src))
+
+	require.NoError(t, os.WriteFile(modFile, []byte("package dep\n\nfunc G() {\n\tg()\n}\n"), 0644))
+	require.NoError(t, os.Chtimes(modFile, time.Now(), time.Now().Add(time.Minute)))
+
+	src, err = cache.source(modFile, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, "package dep\n\nfunc G() {\n\n}\n", string(src))
+
+	// and by the Go version for GOROOT
+	cache.goroot = modDir + string(filepath.Separator)
+
+	entries := func() int {
+		cached, err := filepath.Glob(filepath.Join(dir, "cache", "*", "*"))
+		require.NoError(t, err)
+
+		return len(cached)
+	}
+
+	count := entries()
+
+	_, err = cache.source(modFile, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, entries())
+
+	cache.goVersion = "go1.0"
+
+	_, err = cache.source(modFile, false, ParseAll)
+	require.NoError(t, err)
+	assert.Equal(t, count+2, entries())
+
+	// the models of a file only are stored apart, as an empty entry if it declares neither types nor constants
+	count = entries()
+
+	for i := 0; i < 2; i++ {
+		src, err = cache.source(modFile, false, ParseModels)
+		require.NoError(t, err)
+		assert.Nil(t, src)
+		assert.Equal(t, count+1, entries())
+	}
+
+	typesFile := filepath.Join(modDir, "types.go")
+	require.NoError(t, os.WriteFile(typesFile, []byte("package dep\n\nfunc F() {\n\tf()\n}\n\ntype T int\n"), 0644))
+
+	src, err = cache.source(typesFile, false, ParseModels)
+	require.NoError(t, err)
+	assert.Equal(t, "package dep\n\n\n\n\n\ntype T int\n", string(src))
+
+	_, err = cache.source(filepath.Join(srcDir, "missing.go"), false, ParseAll)
+	assert.Error(t, err)
+}
+
+func TestParseCache_Trim(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cache := newParseCache(dir, log.New(os.Stdout, "", 0))
+
+	old := time.Now().Add(-parseCacheMaxAge - time.Hour)
+	unused := filepath.Join(dir, "ab", "unused")
+	used := filepath.Join(dir, "ab", "used")
+
+	for _, file := range []string{unused, used} {
+		require.NoError(t, writeCacheFile(file, []byte("package api\n")))
+		require.NoError(t, os.Chtimes(file, old, old))
+	}
+
+	// a used entry is kept
+	cache.touch(used)
+	cache.trim()
+
+	assert.NoFileExists(t, unused)
+	assert.FileExists(t, used)
+	assert.FileExists(t, filepath.Join(dir, parseCacheTrimFile))
+
+	// the entries are removed at most every parseCacheTrimInterval
+	require.NoError(t, os.Chtimes(used, old, old))
+	cache.trim()
+	assert.FileExists(t, used)
+}
+
+func TestParseCache_ParseAPI(t *testing.T) {
+	for _, searchDir := range []string{"testdata/versions", "testdata/nested"} {
+		// the models of the dependencies of nested are parsed only
+		p := New(SetParseDependency(1))
+		require.NoError(t, p.ParseAPIMultiSearchDir([]string{searchDir}, "main.go", defaultParseDepth))
+
+		expected, err := json.Marshal(p.GetSwagger())
+		require.NoError(t, err)
+
+		cacheDir := t.TempDir()
+
+		for i := 0; i < 2; i++ {
+			p = New(SetParseDependency(1), SetParseCacheDirectory(cacheDir))
+			require.NoError(t, p.ParseAPIMultiSearchDir([]string{searchDir}, "main.go", defaultParseDepth))
+
+			doc, err := json.Marshal(p.GetSwagger())
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(doc))
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package swag
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,9 +18,10 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
-	"github.com/KyleBanks/depth"
 	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag/internal/load"
 )
 
 const (
@@ -69,6 +69,18 @@ const (
 	xCodeSamplesAttr        = "@x-codesamples"
 	scopeAttrPrefix         = "@scope."
 	stateAttr               = "@state"
+	defaultsAttr            = "@swag.defaults"
+	hostAttr                = "@host"
+	serverAttr              = "@server"
+	serversAttr             = "@servers"
+	webhookAttr             = "@webhook"
+	callbackAttr            = "@callback"
+	linkAttr                = "@link"
+	streamAttr              = "@stream"
+	visibilityAttr          = "@visibility"
+	jsonSchemaAttr          = "@jsonschema"
+	sinceAttr               = "@since"
+	changedAttr             = "@changed"
 )
 
 // ParseFlag determine what to parse
@@ -109,6 +121,18 @@ var allMethod = map[string]struct{}{
 	http.MethodPatch:   {},
 }
 
+// sortedMethods returns the HTTP methods of allMethod in alphabetic order.
+func sortedMethods() []string {
+	methods := make([]string, 0, len(allMethod))
+	for method := range allMethod {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+
+	return methods
+}
+
 // Parser implements a parser for Go source files.
 type Parser struct {
 	// swagger represents the root document object for the API specification
@@ -172,9 +196,6 @@ type Parser struct {
 	// Overrides allows global replacements of types. A blank replacement will be skipped.
 	Overrides map[string]string
 
-	// parseGoList whether swag use go list to parse dependency
-	parseGoList bool
-
 	// tags to filter the APIs after
 	tags map[string]struct{}
 
@@ -183,6 +204,73 @@ type Parser struct {
 
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
+
+	// operationDefaults store the `@swag.defaults` blocks merged into operations
+	operationDefaults []*operationDefaults
+
+	// callbacks store the callback definitions by name and expression
+	callbacks map[string]map[string]spec.PathItem
+
+	// jsonSchemaTypes the names of the types selected for the JSON Schema output, besides the annotated ones
+	jsonSchemaTypes []string
+
+	// jsonSchemaRoots the definitions of the types selected for the JSON Schema output
+	jsonSchemaRoots []string
+
+	// jsonSchemaDefinitions the definitions only the types selected for the JSON Schema output refer to
+	jsonSchemaDefinitions spec.Definitions
+
+	// jobs the number of files parsed concurrently, as many as CPUs if 0
+	jobs int
+
+	// schemaMutex serializes the parsing of the schemas of the operations parsed concurrently
+	schemaMutex sync.Mutex
+
+	// parseCacheDir the directory of the parse cache, disabled if empty
+	parseCacheDir string
+
+	// parseCache stores the sources of the parsed files stripped of what swag doesn't read
+	parseCache *parseCache
+
+	// buildTags the build tags the build constraints of the files are evaluated with
+	buildTags []string
+
+	// goos the target operating system the packages are loaded for, the one of the go command if empty
+	goos string
+
+	// goarch the target architecture the packages are loaded for, the one of the go command if empty
+	goarch string
+
+	// buildContext evaluates the build constraints of the files of the search dirs
+	buildContext build.Context
+
+	// loader loads the dependencies and the packages of the gin handlers once, in the dir of the main API file
+	loader *load.Loader
+
+	// modules finds the modules the parsed files belong to
+	modules *load.Modules
+
+	// funcDocs the number of comments of the funcs before the ones built from their gin handlers were appended
+	funcDocs map[*ast.FuncDecl]int
+
+	// funcDetailComments the comments built from the gin handlers of each file, by path
+	funcDetailComments map[string]string
+
+	// schemaParents the types whose schemas are being parsed, the innermost last
+	schemaParents []*TypeSpecDef
+
+	// schemaFile the file of the operation whose schemas are being parsed, nil outside of the operations
+	schemaFile *ast.File
+
+	// schemaDependents the types and the files of the operations which used the schema of a type, by type,
+	// with whether they referred to its definition, so Reparse rebuilds only what depends on the changed files
+	schemaDependents map[*TypeSpecDef]map[schemaDependent]bool
+
+	// fileRoutes the routes of the operations of each file
+	fileRoutes map[*ast.File][]RouteProperties
+
+	// built whether the document was built without error, so Reparse can rebuild only a part of it
+	built bool
 }
 
 // FieldParserFactory create FieldParser.
@@ -208,30 +296,7 @@ type Debugger interface {
 // New creates a new Parser with default properties.
 func New(options ...func(*Parser)) *Parser {
 	parser := &Parser{
-		swagger: &spec.Swagger{
-			SwaggerProps: spec.SwaggerProps{
-				Info: &spec.Info{
-					InfoProps: spec.InfoProps{
-						Contact: &spec.ContactInfo{},
-						License: nil,
-					},
-					VendorExtensible: spec.VendorExtensible{
-						Extensions: spec.Extensions{},
-					},
-				},
-				Paths: &spec.Paths{
-					Paths: make(map[string]spec.PathItem),
-					VendorExtensible: spec.VendorExtensible{
-						Extensions: nil,
-					},
-				},
-				Definitions:         make(map[string]spec.Schema),
-				SecurityDefinitions: make(map[string]*spec.SecurityScheme),
-			},
-			VendorExtensible: spec.VendorExtensible{
-				Extensions: nil,
-			},
-		},
+		swagger:            newSwagger(),
 		packages:           NewPackagesDefinitions(),
 		debug:              log.New(os.Stdout, "", log.LstdFlags),
 		parsedSchemas:      make(map[*TypeSpecDef]*Schema),
@@ -240,6 +305,7 @@ func New(options ...func(*Parser)) *Parser {
 		tags:               make(map[string]struct{}),
 		fieldParserFactory: newTagBaseFieldParser,
 		Overrides:          make(map[string]string),
+		jobs:               1,
 	}
 
 	for _, option := range options {
@@ -247,10 +313,49 @@ func New(options ...func(*Parser)) *Parser {
 	}
 
 	parser.packages.debug = parser.debug
+	parser.packages.jobs = parser.jobs
+
+	parser.modules = load.NewModules()
+	// the packages are loaded from the current directory until the directory of the main API file is known
+	parser.packages.loader = load.New("", parser.buildTags, parser.buildEnv())
+	parser.buildContext = newBuildContext(parser.buildTags, parser.goos, parser.goarch)
+	parser.packages.readFile = parser.readFile
+
+	if parser.parseCacheDir != "" {
+		parser.parseCache = newParseCache(parser.parseCacheDir, parser.debug)
+	}
 
 	return parser
 }
 
+// newSwagger creates the empty swagger document the parser fills in.
+func newSwagger() *spec.Swagger {
+	return &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Info: &spec.Info{
+				InfoProps: spec.InfoProps{
+					Contact: &spec.ContactInfo{},
+					License: nil,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{},
+				},
+			},
+			Paths: &spec.Paths{
+				Paths: make(map[string]spec.PathItem),
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: nil,
+				},
+			},
+			Definitions:         make(map[string]spec.Schema),
+			SecurityDefinitions: make(map[string]*spec.SecurityScheme),
+		},
+		VendorExtensible: spec.VendorExtensible{
+			Extensions: nil,
+		},
+	}
+}
+
 // SetParseDependency sets whether to parse the dependent packages.
 func SetParseDependency(parseDependency int) func(*Parser) {
 	return func(p *Parser) {
@@ -261,6 +366,13 @@ func SetParseDependency(parseDependency int) func(*Parser) {
 	}
 }
 
+// SetJobs sets the number of files parsed concurrently, as many as CPUs if 0.
+func SetJobs(jobs int) func(*Parser) {
+	return func(p *Parser) {
+		p.jobs = jobs
+	}
+}
+
 // SetMarkdownFileDirectory sets the directory to search for markdown files.
 func SetMarkdownFileDirectory(directoryPath string) func(*Parser) {
 	return func(p *Parser) {
@@ -288,6 +400,14 @@ func SetExcludedDirsAndFiles(excludes string) func(*Parser) {
 	}
 }
 
+// SetParseCacheDirectory sets the directory of the parse cache, storing the sources of the parsed files
+// stripped of what swag doesn't read across runs.
+func SetParseCacheDirectory(directoryPath string) func(*Parser) {
+	return func(p *Parser) {
+		p.parseCacheDir = directoryPath
+	}
+}
+
 // SetPackagePrefix sets a list of package path prefixes from a comma-separated
 // string, packages that do not match any one of them will be excluded when
 // searching.
@@ -360,10 +480,35 @@ func SetCollectionFormat(collectionFormat string) func(*Parser) {
 	}
 }
 
-// ParseUsingGoList sets whether swag use go list to parse dependency
-func ParseUsingGoList(enabled bool) func(parser *Parser) {
+// SetJSONSchemaTypes selects types for the JSON Schema output by name, eg: model.Account or
+// github.com/example/model.Account, in addition to the types annotated with @JSONSchema.
+func SetJSONSchemaTypes(types []string) func(*Parser) {
+	return func(p *Parser) {
+		p.jsonSchemaTypes = types
+	}
+}
+
+// ParseUsingGoList sets whether swag use go list to parse dependency.
+//
+// Deprecated: the dependencies are always loaded with go/packages, the way the go command resolves them.
+func ParseUsingGoList(_ bool) func(parser *Parser) {
+	return func(_ *Parser) {}
+}
+
+// SetBuildTags sets the build tags the files are parsed for: the build constraints of the files of the search dirs
+// are evaluated with them, and the dependencies and the packages of the gin handlers are loaded with them.
+func SetBuildTags(tags []string) func(*Parser) {
+	return func(p *Parser) {
+		p.buildTags = tags
+	}
+}
+
+// SetBuildTarget sets the target operating system and architecture the files are parsed for, like GOOS and GOARCH.
+// Empty ones default to the ones of the go command.
+func SetBuildTarget(goos, goarch string) func(*Parser) {
 	return func(p *Parser) {
-		p.parseGoList = enabled
+		p.goos = goos
+		p.goarch = goarch
 	}
 }
 
@@ -388,21 +533,103 @@ func (parser *Parser) skipPackageByPrefix(pkgpath string) bool {
 
 // ParseAPIMultiSearchDir is like ParseAPI but for multiple search dirs.
 func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile string, parseDepth int) error {
+	absMainAPIFilePath, err := filepath.Abs(filepath.Join(searchDirs[0], mainAPIFile))
+	if err != nil {
+		return err
+	}
+
+	if parser.loader == nil {
+		parser.loader = load.New(filepath.Dir(absMainAPIFilePath), parser.buildTags, parser.buildEnv())
+		parser.packages.loader = parser.loader
+
+		parser.packages.workspace, err = load.WorkspaceModules(filepath.Dir(absMainAPIFilePath))
+		if err != nil {
+			return fmt.Errorf("failed to read the go.work file: %w", err)
+		}
+	}
+
 	var fdList []*gin.FileDetail
 	for _, searchDir := range searchDirs {
 		parser.debug.Printf("Generate general API Info, search dir:%s", searchDir)
 
-		packageDir, err := getPkgName(searchDir)
+		packageDir := parser.searchDirPackagePath(searchDir)
+
+		details, err := parser.ginDetails(searchDir)
 		if err != nil {
-			parser.debug.Printf("warning: failed to get package name in dir: %s, error: %s", searchDir, err.Error())
+			return err
 		}
-		fdList = append(fdList, gin.ParseDetail(searchDir)...)
+
+		fdList = append(fdList, details...)
 
 		err = parser.getAllGoFileInfo(packageDir, searchDir)
 		if err != nil {
 			return err
 		}
 	}
+	parser.addFuncDetails(fdList)
+
+	if parser.ParseDependency > 0 {
+		files, err := parser.dependencyFiles(filepath.Dir(absMainAPIFilePath), parseDepth)
+		if err != nil {
+			return err
+		}
+
+		if err := parser.parseFiles(files); err != nil {
+			return err
+		}
+	}
+
+	return parser.parseAPI(absMainAPIFilePath)
+}
+
+// ginDetails returns the details of the gin handlers of the packages in searchDir.
+func (parser *Parser) ginDetails(searchDir string) ([]*gin.FileDetail, error) {
+	var details []*gin.FileDetail
+
+	for _, dir := range parser.moduleDirs(searchDir) {
+		pkgs, err := parser.loader.Typed(dir, "./...")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the packages of %s: %w", dir, err)
+		}
+
+		details = append(details, gin.ParseDetail(pkgs)...)
+	}
+
+	return details, nil
+}
+
+// moduleDirs returns the dirs the packages of searchDir are loaded from: searchDir if it is in a module,
+// and the dirs of the workspace modules nested in it, which the go command loads separately.
+func (parser *Parser) moduleDirs(searchDir string) []string {
+	absSearchDir, err := filepath.Abs(searchDir)
+	if err != nil {
+		return []string{searchDir}
+	}
+
+	var dirs []string
+
+	if _, ok, _ := parser.modules.PackagePath(absSearchDir); ok || len(parser.packages.workspace) == 0 {
+		dirs = append(dirs, searchDir)
+	}
+
+	for _, module := range parser.packages.workspace {
+		if strings.HasPrefix(module.Dir, absSearchDir+string(filepath.Separator)) {
+			dirs = append(dirs, module.Dir)
+		}
+	}
+
+	return dirs
+}
+
+// addFuncDetails appends the comments built from the gin handlers to the docs of their funcs,
+// replacing the ones appended before.
+func (parser *Parser) addFuncDetails(fdList []*gin.FileDetail) {
+	if parser.funcDocs == nil {
+		parser.funcDocs = make(map[*ast.FuncDecl]int)
+	}
+
+	parser.funcDetailComments = funcDetailComments(fdList)
+
 	//给方法加上我们自己的注释
 	for file, astFileInfo := range parser.packages.files {
 		for _, v := range fdList {
@@ -422,6 +649,11 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 					if f.Doc == nil {
 						f.Doc = &ast.CommentGroup{}
 					}
+					if n, ok := parser.funcDocs[f]; ok {
+						f.Doc.List = f.Doc.List[:n]
+					} else {
+						parser.funcDocs[f] = len(f.Doc.List)
+					}
 					f.Doc.List = append(f.Doc.List, funcDetail.BuildComment()...)
 				}
 
@@ -429,67 +661,84 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 
 		}
 	}
+}
 
-	absMainAPIFilePath, err := filepath.Abs(filepath.Join(searchDirs[0], mainAPIFile))
+// parseAPI parses the general API info of the main file, then the types and operations of the collected files.
+func (parser *Parser) parseAPI(absMainAPIFilePath string) error {
+	parser.built = false
+
+	//解析
+	err := parser.ParseGeneralAPIInfo(absMainAPIFilePath)
 	if err != nil {
 		return err
 	}
 
-	// Use 'go list' command instead of depth.Resolve()
-	if parser.ParseDependency > 0 {
-		if parser.parseGoList {
-			pkgs, err := listPackages(context.Background(), filepath.Dir(absMainAPIFilePath), nil, "-deps")
-			if err != nil {
-				return fmt.Errorf("pkg %s cannot find all dependencies, %s", filepath.Dir(absMainAPIFilePath), err)
-			}
-
-			length := len(pkgs)
-			for i := 0; i < length; i++ {
-				err := parser.getAllGoFileInfoFromDepsByList(pkgs[i], parser.ParseDependency)
-				if err != nil {
-					return err
-				}
-			}
-		} else {
-			var t depth.Tree
-			t.ResolveInternal = true
-			t.MaxDepth = parseDepth
+	parser.parsedSchemas, err = parser.packages.ParseTypes()
+	if err != nil {
+		return err
+	}
 
-			pkgName, err := getPkgName(filepath.Dir(absMainAPIFilePath))
-			if err != nil {
-				return err
-			}
+	err = parser.packages.RangeFiles(parser.parseOperationDefaults)
+	if err != nil {
+		return err
+	}
 
-			err = t.Resolve(pkgName)
-			if err != nil {
-				return fmt.Errorf("pkg %s cannot find all dependencies, %s", pkgName, err)
-			}
-			for i := 0; i < len(t.Root.Deps); i++ {
-				err := parser.getAllGoFileInfoFromDeps(&t.Root.Deps[i], parser.ParseDependency)
-				if err != nil {
-					return err
-				}
-			}
-		}
+	err = parser.packages.RangeFiles(parser.parseWebhooksAndCallbacks)
+	if err != nil {
+		return err
 	}
 
-	//解析
-	err = parser.ParseGeneralAPIInfo(absMainAPIFilePath)
+	err = parser.parseOperations()
 	if err != nil {
 		return err
 	}
 
-	parser.parsedSchemas, err = parser.packages.ParseTypes()
+	err = parser.parseJSONSchemaTypes()
 	if err != nil {
 		return err
 	}
 
-	err = parser.packages.RangeFiles(parser.ParseRouterAPIInfo)
+	err = parser.checkOperationIDUniqueness()
 	if err != nil {
 		return err
 	}
 
-	return parser.checkOperationIDUniqueness()
+	err = parser.checkOperationLinks()
+	parser.built = err == nil
+
+	return err
+}
+
+// searchDirPackagePath returns the import path of the package in searchDir, from its module or from go list.
+func (parser *Parser) searchDirPackagePath(searchDir string) string {
+	pkgPath, ok, err := parser.modules.PackagePath(searchDir)
+	if err == nil && ok {
+		return pkgPath
+	}
+
+	pkgPath, err = getPkgName(searchDir)
+	if err != nil {
+		parser.debug.Printf("warning: failed to get package name in dir: %s, error: %s", searchDir, err.Error())
+	}
+
+	return pkgPath
+}
+
+// filePackagePath returns the import path of the package of the file of path in searchDir: the one of its
+// module, which isn't the module of searchDir in a multi-module search dir, or packageDir, the import path
+// of searchDir, followed by the relative path of the file dir outside modules.
+func (parser *Parser) filePackagePath(packageDir, searchDir, path string) (string, error) {
+	pkgPath, ok, err := parser.modules.PackagePath(filepath.Dir(path))
+	if err != nil || ok {
+		return pkgPath, err
+	}
+
+	relPath, err := filepath.Rel(searchDir, path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(filepath.Dir(filepath.Clean(filepath.Join(packageDir, relPath)))), nil
 }
 
 func getPkgName(searchDir string) (string, error) {
@@ -579,7 +828,7 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 
 			setSwaggerInfo(parser.swagger, descriptionAttr, string(commentInfo))
 
-		case "@host":
+		case hostAttr:
 			parser.swagger.Host = value
 		case "@hoststate":
 			fields = FieldsByAnySpace(commentLine, 3)
@@ -634,6 +883,15 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 					URL: value,
 				}
 			}
+		case "@tag.server", "@tag.servers":
+			if tag != nil {
+				server, err := parseServer(attribute, value)
+				if err != nil {
+					return err
+				}
+
+				tag.Extensions = addServer(tag.Extensions, server)
+			}
 		case "@tag.docs.description":
 			if tag != nil {
 				if tag.TagProps.ExternalDocs == nil {
@@ -924,7 +1182,7 @@ func isGeneralAPIComment(comments []string) bool {
 		attribute := strings.ToLower(FieldsByAnySpace(commentLine, 2)[0])
 		switch attribute {
 		// The @summary, @router, @success, @failure annotation belongs to Operation
-		case summaryAttr, routerAttr, successAttr, failureAttr, responseAttr:
+		case summaryAttr, routerAttr, successAttr, failureAttr, responseAttr, defaultsAttr, webhookAttr, callbackAttr:
 			return false
 		}
 	}
@@ -1025,19 +1283,33 @@ func (parser *Parser) matchTag(tag string) bool {
 }
 
 func (parser *Parser) matchTags(comments []*ast.Comment) (match bool) {
+	return parser.matchTagNames(getCommentsTags(comments))
+}
+
+// getCommentsTags returns the tags of the @Tags lines of comments.
+func getCommentsTags(comments []*ast.Comment) []string {
+	var tags []string
+
+	for _, comment := range comments {
+		tags = append(tags, getTagsFromComment(comment.Text)...)
+	}
+
+	return tags
+}
+
+// matchTagNames reports whether the operation of tags is kept by the tags the parser is restricted to.
+func (parser *Parser) matchTagNames(tags []string) (match bool) {
 	if len(parser.tags) == 0 {
 		return true
 	}
 
 	match = false
-	for _, comment := range comments {
-		for _, tag := range getTagsFromComment(comment.Text) {
-			if _, has := parser.tags["!"+tag]; has {
-				return false
-			}
-			if _, has := parser.tags[tag]; has {
-				match = true // keep iterating as it may contain a tag that is excluded
-			}
+	for _, tag := range tags {
+		if _, has := parser.tags["!"+tag]; has {
+			return false
+		}
+		if _, has := parser.tags[tag]; has {
+			match = true // keep iterating as it may contain a tag that is excluded
 		}
 	}
 
@@ -1097,7 +1369,86 @@ func getFuncDoc(decl any) (*ast.CommentGroup, bool) {
 
 // ParseRouterAPIInfo parses router api info for given astFile.
 func (parser *Parser) ParseRouterAPIInfo(fileInfo *AstFileInfo) error {
+	operations, err := parser.parseFileOperations(fileInfo)
+
+	if err := parser.addOperations(fileInfo, operations); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// parseOperations parses the operations of the files concurrently, then adds them to the paths one file after
+// another, so the document is the same as when ParseRouterAPIInfo parses the files one after another.
+func (parser *Parser) parseOperations() error {
+	var files []*AstFileInfo
+
+	_ = parser.packages.RangeFiles(func(info *AstFileInfo) error {
+		files = append(files, info)
+
+		return nil
+	})
+
+	type fileOperations struct {
+		operations []*Operation
+		err        error
+	}
+
+	parsed := make([]fileOperations, len(files))
+
+	forEachJob(parser.jobs, len(files), func(i int) {
+		parsed[i].operations, parsed[i].err = parser.parseFileOperations(files[i])
+	})
+
+	for i := range files {
+		if err := parser.addOperations(files[i], parsed[i].operations); err != nil {
+			return err
+		}
+
+		if parsed[i].err != nil {
+			return parsed[i].err
+		}
+	}
+
+	return nil
+}
+
+// addOperations adds the operations of the file of fileInfo to the paths, recording their routes.
+func (parser *Parser) addOperations(fileInfo *AstFileInfo, operations []*Operation) error {
+	if parser.fileRoutes == nil {
+		parser.fileRoutes = make(map[*ast.File][]RouteProperties)
+	}
+
+	for _, operation := range operations {
+		if err := processRouterOperation(parser, operation); err != nil {
+			return err
+		}
+
+		parser.fileRoutes[fileInfo.File] = append(parser.fileRoutes[fileInfo.File], operation.RouterProperties...)
+	}
+
+	return nil
+}
+
+// parseFileOperations parses the operations of the comments of given astFile without adding them to the paths,
+// returning the ones parsed before an error.
+func (parser *Parser) parseFileOperations(fileInfo *AstFileInfo) ([]*Operation, error) {
 	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
+		return nil, nil
+	}
+
+	var operations []*Operation
+
+	parse := func(comments []*ast.Comment) error {
+		operation, err := parser.parseRouterAPIInfoComment(comments, fileInfo)
+		if err != nil {
+			return err
+		}
+
+		if operation != nil {
+			operations = append(operations, operation)
+		}
+
 		return nil
 	}
 
@@ -1105,47 +1456,78 @@ func (parser *Parser) ParseRouterAPIInfo(fileInfo *AstFileInfo) error {
 	if parser.ParseFuncBody {
 		for _, astComments := range fileInfo.File.Comments {
 			if astComments.List != nil {
-				if err := parser.parseRouterAPIInfoComment(astComments.List, fileInfo); err != nil {
-					return err
+				if err := parse(astComments.List); err != nil {
+					return operations, err
 				}
 			}
 		}
 
-		return nil
+		return operations, nil
 	}
 
 	for _, decl := range fileInfo.File.Decls {
 		funcDoc, ok := getFuncDoc(decl)
 		if ok && funcDoc != nil && funcDoc.List != nil {
-			if err := parser.parseRouterAPIInfoComment(funcDoc.List, fileInfo); err != nil {
-				return err
+			if err := parse(funcDoc.List); err != nil {
+				return operations, err
 			}
 		}
 	}
 
-	return nil
+	return operations, nil
 }
 
-func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInfo *AstFileInfo) error {
-	if parser.matchTags(comments) && matchExtension(parser.parseExtension, comments) {
-		// for per 'function' comment, create a new 'Operation' object
-		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
-		for _, comment := range comments {
-			err := operation.ParseComment(comment.Text, fileInfo.File)
-			if err != nil {
-				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
-			}
-			if operation.State != "" && operation.State != parser.HostState {
-				return nil
-			}
-		}
-		err := processRouterOperation(parser, operation)
+func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInfo *AstFileInfo) (*Operation, error) {
+	if isOperationDefaultsComment(comments) || isWebhookOrCallbackComment(comments) {
+		return nil, nil
+	}
+
+	// the operations without @Tags inherit the ones of their defaults, they are matched once merged
+	if tags := getCommentsTags(comments); len(tags) > 0 && !parser.matchTagNames(tags) ||
+		!matchExtension(parser.parseExtension, comments) {
+		return nil, nil
+	}
+
+	// for per 'function' comment, create a new 'Operation' object
+	operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+	for _, comment := range comments {
+		err := operation.ParseComment(comment.Text, fileInfo.File)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
+		}
+		if operation.State != "" && operation.State != parser.HostState {
+			return nil, nil
 		}
 	}
+	parser.applyOperationDefaults(operation, fileInfo.PackagePath)
 
-	return nil
+	if !parser.matchTagNames(operation.Tags) {
+		return nil, nil
+	}
+
+	parser.applyTagServers(operation)
+	err := parser.applyCallbacks(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// lockSchemas locks the parsing of schemas for an operation of file parsed concurrently, returning the func
+// unlocking it.
+func (parser *Parser) lockSchemas(file *ast.File) func() {
+	if parser == nil {
+		return func() {}
+	}
+
+	parser.schemaMutex.Lock()
+	parser.schemaFile = file
+
+	return func() {
+		parser.schemaFile = nil
+		parser.schemaMutex.Unlock()
+	}
 }
 
 func refRouteMethodOp(item *spec.PathItem, method string) (op **spec.Operation) {
@@ -1278,6 +1660,8 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 		typeSpecDef = parser.packages.findTypeSpec(override[0:separator], override[separator+1:])
 	}
 
+	parser.addSchemaDependent(typeSpecDef, false)
+
 	schema, ok := parser.parsedSchemas[typeSpecDef]
 	if !ok {
 		var err error
@@ -1304,6 +1688,8 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 }
 
 func (parser *Parser) getRefTypeSchema(typeSpecDef *TypeSpecDef, schema *Schema) *spec.Schema {
+	parser.addSchemaDependent(typeSpecDef, true)
+
 	_, ok := parser.outputSchemas[typeSpecDef]
 	if !ok {
 		parser.swagger.Definitions[schema.Name] = spec.Schema{}
@@ -1355,6 +1741,11 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 
 	parser.structStack = append(parser.structStack, typeSpecDef)
 
+	parser.schemaParents = append(parser.schemaParents, typeSpecDef)
+	defer func() {
+		parser.schemaParents = parser.schemaParents[:len(parser.schemaParents)-1]
+	}()
+
 	parser.debug.Printf("Generating %s", typeName)
 
 	definition, err := parser.parseTypeExpr(typeSpecDef.File, typeSpecDef.TypeSpec.Type, false)
@@ -1844,10 +2235,9 @@ func defineTypeOfExample(schemaType, arrayType, exampleValue string) (interface{
 
 // GetAllGoFileInfo gets all Go source files information for given searchDir.
 func (parser *Parser) getAllGoFileInfo(packageDir, searchDir string) error {
-	if parser.skipPackageByPrefix(packageDir) {
-		return nil // ignored by user-defined package path prefixes
-	}
-	return filepath.Walk(searchDir, func(path string, f os.FileInfo, _ error) error {
+	var files, skipped []goFile
+
+	err := filepath.Walk(searchDir, func(path string, f os.FileInfo, _ error) error {
 		err := parser.Skip(path, f)
 		if err != nil {
 			return err
@@ -1857,50 +2247,99 @@ func (parser *Parser) getAllGoFileInfo(packageDir, searchDir string) error {
 			return nil
 		}
 
-		relPath, err := filepath.Rel(searchDir, path)
+		pkgPath, err := parser.filePackagePath(packageDir, searchDir, path)
 		if err != nil {
 			return err
 		}
 
-		return parser.parseFile(filepath.ToSlash(filepath.Dir(filepath.Clean(filepath.Join(packageDir, relPath)))), path, nil, ParseAll)
-	})
-}
+		if parser.skipPackageByPrefix(pkgPath) {
+			return nil // ignored by user-defined package path prefixes
+		}
+
+		file := goFile{
+			packageDir: pkgPath,
+			path:       path,
+			flag:       ParseAll,
+		}
+
+		if isGoSourceFile(path) {
+			matched, err := parser.matchFile(path)
+			if err != nil {
+				return err
+			}
+
+			if !matched {
+				skipped = append(skipped, file)
+
+				return nil
+			}
+		}
+
+		files = append(files, file)
 
-func (parser *Parser) getAllGoFileInfoFromDeps(pkg *depth.Pkg, parseFlag ParseFlag) error {
-	ignoreInternal := pkg.Internal && !parser.ParseInternal
-	if ignoreInternal || !pkg.Resolved { // ignored internal and not resolved dependencies
 		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if pkg.Raw != nil && parser.skipPackageByPrefix(pkg.Raw.ImportPath) {
-		return nil // ignored by user-defined package path prefixes
+	if err := parser.parseFiles(files); err != nil {
+		return err
 	}
 
-	// Skip cgo
-	if pkg.Raw == nil && pkg.Name == "C" {
+	parser.reportConstrainedTypes(skipped)
+
+	return nil
+}
+
+func (parser *Parser) parseFile(packageDir, path string, src interface{}, flag ParseFlag) error {
+	if !isGoSourceFile(path) {
 		return nil
 	}
 
-	srcDir := pkg.Raw.Dir
-
-	files, err := os.ReadDir(srcDir) // only parsing files in the dir(don't contain sub dir files)
-	if err != nil {
+	fileSet, astFile, err := parser.readFile(path, src, flag)
+	if err != nil || astFile == nil {
 		return err
 	}
 
-	for _, f := range files {
-		if f.IsDir() {
-			continue
+	return parser.packages.collectAstFile(fileSet, packageDir, path, astFile, flag)
+}
+
+// goFile a Go file to parse with parseFiles.
+type goFile struct {
+	packageDir string
+	path       string
+	flag       ParseFlag
+}
+
+// parseFiles parses files concurrently, then collects them in their order, so the result is the same
+// as parsing them one after another with parseFile.
+func (parser *Parser) parseFiles(files []goFile) error {
+	type parsedFile struct {
+		fileSet *token.FileSet
+		astFile *ast.File
+		err     error
+	}
+
+	parsed := make([]parsedFile, len(files))
+
+	forEachJob(parser.jobs, len(files), func(i int) {
+		if isGoSourceFile(files[i].path) {
+			parsed[i].fileSet, parsed[i].astFile, parsed[i].err = parser.readFile(files[i].path, nil, files[i].flag)
 		}
+	})
 
-		path := filepath.Join(srcDir, f.Name())
-		if err := parser.parseFile(pkg.Name, path, nil, parseFlag); err != nil {
-			return err
+	for i, file := range files {
+		if parsed[i].err != nil {
+			return parsed[i].err
 		}
-	}
 
-	for i := 0; i < len(pkg.Deps); i++ {
-		if err := parser.getAllGoFileInfoFromDeps(&pkg.Deps[i], parseFlag); err != nil {
+		if parsed[i].astFile == nil {
+			continue
+		}
+
+		err := parser.packages.collectAstFile(parsed[i].fileSet, file.packageDir, file.path, parsed[i].astFile, file.flag)
+		if err != nil {
 			return err
 		}
 	}
@@ -1908,44 +2347,53 @@ func (parser *Parser) getAllGoFileInfoFromDeps(pkg *depth.Pkg, parseFlag ParseFl
 	return nil
 }
 
-func (parser *Parser) parseFile(packageDir, path string, src interface{}, flag ParseFlag) error {
-	if strings.HasSuffix(strings.ToLower(path), "_test.go") || filepath.Ext(path) != ".go" {
-		return nil
+// readFile parses the Go file of path parsed with flag, from src if not nil, from the parse cache if enabled.
+// It returns a nil file if the parse cache knows the file declares nothing swag reads.
+func (parser *Parser) readFile(path string, src interface{}, flag ParseFlag) (*token.FileSet, *ast.File, error) {
+	if parser.parseCache != nil && src == nil {
+		stripped, err := parser.parseCache.source(path, parser.ParseFuncBody, flag)
+		if err != nil || stripped == nil {
+			return nil, nil, err
+		}
+
+		src = stripped
 	}
 
-	return parser.packages.ParseFile(packageDir, path, src, flag)
+	return parseGoFile(path, src)
 }
 
 func (parser *Parser) checkOperationIDUniqueness() error {
 	// operationsIds contains all operationId annotations to check it's unique
 	operationsIds := make(map[string]string)
 
-	for path, item := range parser.swagger.Paths.Paths {
-		var method, id string
+	paths := make([]string, 0, len(parser.swagger.Paths.Paths))
+	for path := range parser.swagger.Paths.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
 
-		for method = range allMethod {
-			op := refRouteMethodOp(&item, method)
-			if *op != nil {
-				id = (**op).ID
+	for _, path := range paths {
+		item := parser.swagger.Paths.Paths[path]
 
-				break
+		for _, method := range sortedMethods() {
+			op := refRouteMethodOp(&item, method)
+			if *op == nil || (**op).ID == "" {
+				continue
 			}
-		}
 
-		if id == "" {
-			continue
-		}
+			id := (**op).ID
+			current := fmt.Sprintf("%s %s", method, path)
 
-		current := fmt.Sprintf("%s %s", method, path)
+			previous, ok := operationsIds[id]
+			if ok {
+				return fmt.Errorf(
+					"duplicated @id annotation '%s' found in '%s', previously declared in: '%s'",
+					id, current, previous)
+			}
 
-		previous, ok := operationsIds[id]
-		if ok {
-			return fmt.Errorf(
-				"duplicated @id annotation '%s' found in '%s', previously declared in: '%s'",
-				id, current, previous)
+			operationsIds[id] = current
 		}
-
-		operationsIds[id] = current
 	}
 
 	return nil
@@ -1981,6 +2429,45 @@ func (parser *Parser) GetSwagger() *spec.Swagger {
 	return parser.swagger
 }
 
+// GoType identifies the Go type a definition is generated from.
+type GoType struct {
+	// PkgPath import path of the package declaring the type.
+	PkgPath string
+
+	// Package name of the package declaring the type.
+	Package string
+
+	// Name of the type.
+	Name string
+}
+
+// DefinitionGoTypes returns the Go types the definitions of the swagger document are generated from,
+// by definition name. Types which can't be referred to from another package, like the ones declared in
+// package main, in a function or generic ones, are left out.
+func (parser *Parser) DefinitionGoTypes() map[string]GoType {
+	goTypes := make(map[string]GoType)
+
+	for typeSpecDef, schema := range parser.outputSchemas {
+		if typeSpecDef.File == nil || typeSpecDef.TypeSpec == nil || typeSpecDef.ParentSpec != nil ||
+			typeSpecDef.TypeSpec.TypeParams != nil || typeSpecDef.File.Name.Name == "main" {
+			continue
+		}
+
+		name := typeSpecDef.Name()
+		if !token.IsExported(name) || !token.IsIdentifier(name) || typeSpecDef.PkgPath == "" {
+			continue
+		}
+
+		goTypes[schema.Name] = GoType{
+			PkgPath: typeSpecDef.PkgPath,
+			Package: typeSpecDef.File.Name.Name,
+			Name:    name,
+		}
+	}
+
+	return goTypes
+}
+
 // addTestType just for tests.
 func (parser *Parser) addTestType(typename string) {
 	typeDef := &TypeSpecDef{}
@@ -2245,6 +2245,48 @@ func TestParseDuplicatedOtherMethods(t *testing.T) {
 	assert.Errorf(t, err, "duplicated @id declarations successfully found")
 }
 
+func TestParser_checkOperationIDUniquenessAllMethods(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @ID getUser
+// @Router /users/{id} [get]
+func Get(){
+}
+
+// @ID getUser
+// @Router /users/{id} [patch]
+func Patch(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.EqualError(t, p.checkOperationIDUniqueness(),
+		"duplicated @id annotation 'getUser' found in 'PATCH /users/{id}', previously declared in: 'GET /users/{id}'")
+
+	src = `
+package api
+
+// @ID options
+// @Router /users [options]
+func Users(){
+}
+
+// @ID options
+// @Router /accounts [head]
+func Accounts(){
+}
+`
+	p = New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	assert.EqualError(t, p.checkOperationIDUniqueness(),
+		"duplicated @id annotation 'options' found in 'OPTIONS /users', previously declared in: 'HEAD /accounts'")
+}
+
 func TestParseDuplicatedFunctionScoped(t *testing.T) {
 	t.Parallel()
 
@@ -2267,6 +2309,35 @@ func TestParseConflictSchemaName(t *testing.T) {
 	assert.Equal(t, string(expected), string(b))
 }
 
+func TestParseDeterministic(t *testing.T) {
+	t.Parallel()
+
+	for _, searchDir := range []string{"testdata/conflict_name", "testdata/enums", "testdata/duplicated2"} {
+		var expected string
+
+		// the files are kept in a map, the output and the errors must not depend on its order
+		for i := 0; i < 5; i++ {
+			p := New(SetParseDependency(1))
+
+			var result string
+
+			err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+			if err != nil {
+				result = err.Error()
+			} else {
+				b, _ := json.MarshalIndent(p.swagger, "", "    ")
+				result = string(b)
+			}
+
+			if i > 0 {
+				assert.Equal(t, expected, result, searchDir)
+			}
+
+			expected = result
+		}
+	}
+}
+
 func TestParseExternalModels(t *testing.T) {
 	searchDir := "testdata/external_models/main"
 	mainAPIFile := "main.go"
@@ -2282,7 +2353,7 @@ func TestParseExternalModels(t *testing.T) {
 
 func TestParseGoList(t *testing.T) {
 	mainAPIFile := "main.go"
-	p := New(ParseUsingGoList(true), SetParseDependency(1))
+	p := New(SetParseDependency(1))
 	go111moduleEnv := os.Getenv("GO111MODULE")
 
 	cases := []struct {
@@ -2381,6 +2452,66 @@ function a() {}`))
 	}
 }
 
+func TestParseWorkspace(t *testing.T) {
+	// the go command rejects -mod=mod in workspace mode
+	t.Setenv("GOFLAGS", "")
+
+	expected, err := os.ReadFile("testdata/workspace/expected.json")
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		searchDirs  []string
+		mainAPIFile string
+		parsed      bool
+	}{
+		{
+			name:        "module",
+			searchDirs:  []string{"testdata/workspace/api"},
+			mainAPIFile: "main.go",
+		},
+		{
+			name:        "modules",
+			searchDirs:  []string{"testdata/workspace/api", "testdata/workspace/models"},
+			mainAPIFile: "main.go",
+			parsed:      true,
+		},
+		{
+			name:        "workspace",
+			searchDirs:  []string{"testdata/workspace"},
+			mainAPIFile: "api/main.go",
+			parsed:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := New()
+
+			err := p.ParseAPIMultiSearchDir(c.searchDirs, c.mainAPIFile, defaultParseDepth)
+			assert.NoError(t, err)
+
+			b, err := json.MarshalIndent(p.swagger, "", "    ")
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(b))
+
+			var pkgPaths []string
+			for _, info := range p.packages.files {
+				pkgPaths = append(pkgPaths, info.PackagePath)
+			}
+
+			assert.Contains(t, pkgPaths, "example.com/workspace/api")
+
+			if c.parsed {
+				assert.Contains(t, pkgPaths, "example.com/workspace/models")
+				assert.Contains(t, pkgPaths, "example.com/workspace/models/tags")
+			} else {
+				assert.NotContains(t, pkgPaths, "example.com/workspace/models")
+			}
+		})
+	}
+}
+
 func TestParser_ParseStructArrayObject(t *testing.T) {
 	t.Parallel()
 
@@ -4376,9 +4507,7 @@ func Test(){
 func TestParser_EmbeddedStructAsOtherAliasGoListNested(t *testing.T) {
 	t.Parallel()
 
-	p := New(SetParseDependency(1), ParseUsingGoList(true))
-
-	p.parseGoList = true
+	p := New(SetParseDependency(1))
 
 	searchDir := "testdata/alias_nested"
 	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
@@ -4422,3 +4551,36 @@ var Func2 = Func
 	assert.NotNil(t, val2.Get)
 	assert.Equal(t, val2.Get.OperationProps.Summary, "generate indirectly pointing")
 }
+
+func TestParser_DefinitionGoTypes(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	Name string
+}
+
+type Page[T any] struct {
+	Items []T
+}
+
+// @Success 200 {object} Page[Pet]
+// @Failure 400 {object} Pet
+// @Router /pets [get]
+func ListPets() {}
+`
+	p := New()
+	_ = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	assert.Contains(t, p.swagger.Definitions, "api.Page-api_Pet")
+	assert.Equal(t, map[string]GoType{
+		"api.Pet": {PkgPath: "api", Package: "api", Name: "Pet"},
+	}, p.DefinitionGoTypes())
+}
@@ -0,0 +1,242 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const definitionsRefPrefix = "#/definitions/"
+
+// PruneReport describes the definitions removed and kept by PruneDefinitions.
+type PruneReport struct {
+	// Pruned the names of the definitions removed, sorted.
+	Pruned []string
+
+	// Kept the reason why each remaining definition is kept, by definition name.
+	Kept map[string]string
+}
+
+// PruneDefinitions removes the definitions of swagger which are not reachable from its paths, parameters,
+// responses, security definitions or extensions, unless their name matches one of keep patterns, eg: model.*.
+func PruneDefinitions(swagger *spec.Swagger, keep []string) (*PruneReport, error) {
+	kept, err := reachableDefinitions(swagger, keep)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PruneReport{Kept: kept}
+
+	for name := range swagger.Definitions {
+		if _, ok := kept[name]; !ok {
+			report.Pruned = append(report.Pruned, name)
+			delete(swagger.Definitions, name)
+		}
+	}
+
+	sort.Strings(report.Pruned)
+
+	return report, nil
+}
+
+type reachabilityRoot struct {
+	reason string
+	value  interface{}
+}
+
+// reachableDefinitions returns the definitions referenced from anywhere in swagger but the definitions themselves,
+// directly or through other definitions, and the definitions matching keep patterns, along with the reason why.
+func reachableDefinitions(swagger *spec.Swagger, keep []string) (map[string]string, error) {
+	var names []string
+	for name := range swagger.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	reachable := make(map[string]string)
+
+	var queue []string
+
+	for _, pattern := range keep {
+		for _, name := range names {
+			matched, err := path.Match(pattern, name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid definition pattern %s: %w", pattern, err)
+			}
+
+			if _, ok := reachable[name]; matched && !ok {
+				reachable[name] = "matches " + pattern
+				queue = append(queue, name)
+			}
+		}
+	}
+
+	for _, root := range reachabilityRoots(swagger) {
+		refs, err := collectDefinitionRefs(root.value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ref := range refs {
+			if _, ok := swagger.Definitions[ref]; !ok {
+				continue
+			}
+
+			if _, ok := reachable[ref]; !ok {
+				reachable[ref] = "referenced by " + root.reason
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		refs, err := collectDefinitionRefs(swagger.Definitions[name])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ref := range refs {
+			if _, ok := swagger.Definitions[ref]; !ok {
+				continue
+			}
+
+			if _, ok := reachable[ref]; !ok {
+				reachable[ref] = "referenced by definition " + name
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	return reachable, nil
+}
+
+// reachabilityRoots returns the parts of swagger definitions are reached from, in a stable order.
+func reachabilityRoots(swagger *spec.Swagger) []reachabilityRoot {
+	var roots []reachabilityRoot
+
+	if swagger.Paths != nil {
+		var paths []string
+		for p := range swagger.Paths.Paths {
+			paths = append(paths, p)
+		}
+
+		sort.Strings(paths)
+
+		var methods []string
+		for method := range allMethod {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, p := range paths {
+			item := swagger.Paths.Paths[p]
+
+			if len(item.Parameters) > 0 {
+				roots = append(roots, reachabilityRoot{reason: "parameters of " + p, value: item.Parameters})
+			}
+
+			for _, method := range methods {
+				op := refRouteMethodOp(&item, method)
+				if *op != nil {
+					roots = append(roots, reachabilityRoot{reason: method + " " + p, value: *op})
+				}
+			}
+		}
+	}
+
+	values := make(map[string]interface{})
+	for name, param := range swagger.Parameters {
+		values["parameter "+name] = param
+	}
+
+	for name, response := range swagger.Responses {
+		values["response "+name] = response
+	}
+
+	for name, scheme := range swagger.SecurityDefinitions {
+		values["security definition "+name] = scheme
+	}
+
+	for key, extension := range swagger.Extensions {
+		values["extension "+key] = extension
+	}
+
+	return appendSortedRoots(roots, values)
+}
+
+func appendSortedRoots(roots []reachabilityRoot, values map[string]interface{}) []reachabilityRoot {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		roots = append(roots, reachabilityRoot{reason: key, value: values[key]})
+	}
+
+	return roots
+}
+
+// collectDefinitionRefs returns the names of the definitions referenced by $ref in v,
+// extensions included since they are kept as plain values.
+func collectDefinitionRefs(v interface{}) ([]string, error) {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree interface{}
+
+	err = json.Unmarshal(doc, &tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			var keys []string
+			for key := range value {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				if ref, ok := value[key].(string); ok && key == "$ref" && strings.HasPrefix(ref, definitionsRefPrefix) {
+					refs = append(refs, unescapeJSONPointer(strings.TrimPrefix(ref, definitionsRefPrefix)))
+
+					continue
+				}
+
+				walk(value[key])
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	walk(tree)
+
+	return refs, nil
+}
+
+func unescapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
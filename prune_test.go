@@ -0,0 +1,56 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneDefinitions(t *testing.T) {
+	t.Parallel()
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					"/users": {
+						PathItemProps: spec.PathItemProps{
+							Get: spec.NewOperation("listUsers").RespondsWith(200, spec.NewResponse().WithSchema(spec.ArrayProperty(RefSchema("model.User")))),
+						},
+					},
+				},
+			},
+			Definitions: spec.Definitions{
+				"model.User":    *spec.MapProperty(nil).SetProperty("address", *RefSchema("model.Address")),
+				"model.Address": *PrimitiveSchema(OBJECT),
+				"model.Event":   *PrimitiveSchema(OBJECT),
+				"model.Payload": *PrimitiveSchema(OBJECT),
+				"errors.Error":  *PrimitiveSchema(OBJECT),
+				"other.Unused":  *PrimitiveSchema(OBJECT),
+			},
+			Responses: map[string]spec.Response{
+				"Error": *spec.NewResponse().WithSchema(RefSchema("errors.Error")),
+			},
+		},
+	}
+	swagger.AddExtension(webhooksExtension, map[string]spec.PathItem{
+		"event": {PathItemProps: spec.PathItemProps{Post: spec.NewOperation("").RespondsWith(200, spec.NewResponse().WithSchema(RefSchema("model.Event")))}},
+	})
+
+	report, err := PruneDefinitions(swagger, []string{"model.Pay*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other.Unused"}, report.Pruned)
+	assert.Equal(t, map[string]string{
+		"model.User":    "referenced by GET /users",
+		"model.Address": "referenced by definition model.User",
+		"model.Event":   "referenced by extension x-webhooks",
+		"model.Payload": "matches model.Pay*",
+		"errors.Error":  "referenced by response Error",
+	}, report.Kept)
+	assert.Len(t, swagger.Definitions, 5)
+	assert.NotContains(t, swagger.Definitions, "other.Unused")
+
+	_, err = PruneDefinitions(swagger, []string{"[model"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,698 @@
+package swag
+
+import (
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/swaggo/swag/custom/gin"
+)
+
+// GoFileModTimes returns the modification times of the Go files ParseAPIMultiSearchDir parses in searchDirs,
+// by path.
+func (parser *Parser) GoFileModTimes(searchDirs []string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+
+	for _, searchDir := range searchDirs {
+		err := filepath.Walk(searchDir, func(path string, f os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			err = parser.Skip(path, f)
+			if err != nil || f.IsDir() {
+				return err
+			}
+
+			if isGoSourceFile(path) {
+				modTimes[path] = f.ModTime()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modTimes, nil
+}
+
+// Reparse parses again the given changed, added or removed Go files of searchDirs, keeping the syntax trees of
+// the other files parsed by ParseAPIMultiSearchDir, then builds again the part of the document depending on them:
+// the definitions of the types whose declarations changed and of the types using them, and the operations of the
+// changed files and of the files using any of those types. The whole document is built again instead when the
+// change can affect it anywhere: a file added or removed, a type added, removed or renamed, a constant or a
+// function scoped type changed, a change to the main file, to operation defaults, webhooks or callbacks, JSON
+// Schema types, routes declared more than once, or a previous build which failed.
+// The packages type checked for the gin handlers are loaded again.
+func (parser *Parser) Reparse(searchDirs []string, mainAPIFile string, paths []string) error {
+	absMainAPIFilePath, err := filepath.Abs(filepath.Join(searchDirs[0], mainAPIFile))
+	if err != nil {
+		return err
+	}
+
+	built := parser.built
+	parser.built = false
+
+	changes := make([]fileChange, 0, len(paths))
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+
+		info := parser.packages.removeFile(absPath)
+		changes = append(changes, fileChange{old: info})
+
+		if _, err := os.Stat(absPath); os.IsNotExist(err) {
+			continue
+		}
+
+		matched, err := parser.matchFile(absPath)
+		if err != nil {
+			return err
+		}
+
+		if !matched {
+			continue
+		}
+
+		var (
+			packageDir string
+			flag       ParseFlag = ParseAll
+		)
+
+		if info != nil {
+			packageDir, flag = info.PackagePath, info.ParseFlag
+		} else if packageDir, err = parser.searchDirPackage(searchDirs, path); err != nil {
+			return err
+		}
+
+		if packageDir == "" {
+			continue
+		}
+
+		err = parser.parseFile(packageDir, absPath, nil, flag)
+		if err != nil {
+			return err
+		}
+
+		changes[len(changes)-1].new = parser.packages.fileInfo(absPath)
+	}
+
+	previousDetails := parser.funcDetailComments
+
+	parser.loader.Reset()
+
+	var fdList []*gin.FileDetail
+	for _, searchDir := range searchDirs {
+		details, err := parser.ginDetails(searchDir)
+		if err != nil {
+			return err
+		}
+
+		fdList = append(fdList, details...)
+	}
+
+	parser.addFuncDetails(fdList)
+
+	if built {
+		rebuilt, err := parser.rebuildChanged(changes, absMainAPIFilePath,
+			changedFuncDetails(previousDetails, parser.funcDetailComments))
+		if err != nil || rebuilt {
+			parser.built = err == nil
+
+			return err
+		}
+
+		parser.debug.Printf("Building the whole document again")
+	}
+
+	parser.packages.resetTypes()
+
+	parser.swagger = newSwagger()
+	parser.parsedSchemas = make(map[*TypeSpecDef]*Schema)
+	parser.outputSchemas = make(map[*TypeSpecDef]*Schema)
+	parser.structStack = nil
+	parser.schemaDependents = nil
+	parser.fileRoutes = nil
+	parser.operationDefaults = nil
+	parser.callbacks = nil
+	parser.jsonSchemaRoots = nil
+	parser.jsonSchemaDefinitions = nil
+
+	return parser.parseAPI(absMainAPIFilePath)
+}
+
+// fileChange a file given to Reparse, with its info before and after the change, nil if it wasn't or isn't parsed.
+type fileChange struct {
+	old *AstFileInfo
+	new *AstFileInfo
+}
+
+// schemaDependent a type or the file of an operation which used the schema of a type, neither of them for the
+// other uses, eg: the JSON Schema types.
+type schemaDependent struct {
+	typeSpecDef *TypeSpecDef
+	file        *ast.File
+}
+
+// addSchemaDependent records that the type or the operation whose schema is being parsed uses the schema of
+// typeSpecDef, referring to its definition if ref.
+func (parser *Parser) addSchemaDependent(typeSpecDef *TypeSpecDef, ref bool) {
+	if typeSpecDef == nil {
+		return
+	}
+
+	dependent := schemaDependent{file: parser.schemaFile}
+	if n := len(parser.schemaParents); n > 0 {
+		dependent = schemaDependent{typeSpecDef: parser.schemaParents[n-1]}
+	}
+
+	if parser.schemaDependents == nil {
+		parser.schemaDependents = make(map[*TypeSpecDef]map[schemaDependent]bool)
+	}
+
+	dependents, ok := parser.schemaDependents[typeSpecDef]
+	if !ok {
+		dependents = make(map[schemaDependent]bool)
+		parser.schemaDependents[typeSpecDef] = dependents
+	}
+
+	dependents[dependent] = dependents[dependent] || ref
+}
+
+// rebuildChanged builds again the part of the document depending on changes, see Reparse, reporting false
+// without error when the whole document must be built again instead.
+func (parser *Parser) rebuildChanged(changes []fileChange, absMainAPIFilePath string, detailPaths []string) (bool, error) {
+	if len(parser.jsonSchemaRoots) > 0 || len(parser.jsonSchemaTypes) > 0 {
+		return false, nil
+	}
+
+	// the files whose operations are built again, by their syntax tree before the change
+	affected := make(map[*ast.File]*AstFileInfo)
+
+	// the types whose schemas are parsed again
+	invalid := make(map[*TypeSpecDef]bool)
+
+	for _, change := range changes {
+		if change.old == nil || change.new == nil || !sameDeclarations(change.old, change.new) {
+			return false, nil
+		}
+
+		affected[change.old.File] = change.new
+
+		changed := changedTypes(change.old, change.new)
+
+		for _, typeSpecDef := range parser.packages.uniqueDefinitions {
+			if typeSpecDef.File == change.old.File && isChangedType(typeSpecDef, changed) {
+				invalid[typeSpecDef] = true
+			}
+		}
+
+		for typeSpecDef := range parser.schemaDependents {
+			if typeSpecDef.File == change.old.File && isChangedType(typeSpecDef, changed) {
+				invalid[typeSpecDef] = true
+			}
+		}
+	}
+
+	for _, path := range detailPaths {
+		if info := parser.packages.fileInfo(path); info != nil {
+			if _, ok := affected[info.File]; !ok {
+				affected[info.File] = info
+			}
+		}
+	}
+
+	queue := make([]*TypeSpecDef, 0, len(invalid))
+	for typeSpecDef := range invalid {
+		queue = append(queue, typeSpecDef)
+	}
+
+	for len(queue) > 0 {
+		typeSpecDef := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		for dependent := range parser.schemaDependents[typeSpecDef] {
+			switch {
+			case dependent.typeSpecDef != nil:
+				if !invalid[dependent.typeSpecDef] {
+					invalid[dependent.typeSpecDef] = true
+					queue = append(queue, dependent.typeSpecDef)
+				}
+			case dependent.file != nil:
+				if _, ok := affected[dependent.file]; ok {
+					continue
+				}
+
+				info, ok := parser.packages.files[dependent.file]
+				if !ok {
+					return false, nil
+				}
+
+				affected[dependent.file] = info
+			default:
+				return false, nil
+			}
+		}
+	}
+
+	routes := make(map[string]int)
+	for _, fileRoutes := range parser.fileRoutes {
+		for _, route := range fileRoutes {
+			routes[route.HTTPMethod+" "+route.Path]++
+		}
+	}
+
+	for file, info := range affected {
+		if info.Path == absMainAPIFilePath || declaresSharedComments(file) || declaresSharedComments(info.File) {
+			return false, nil
+		}
+
+		for _, route := range parser.fileRoutes[file] {
+			if routes[route.HTTPMethod+" "+route.Path] > 1 {
+				return false, nil
+			}
+		}
+	}
+
+	parser.forgetSchemas(invalid, affected)
+
+	for _, change := range changes {
+		if !parser.replaceFileTypes(change.old.File, change.new) {
+			return false, nil
+		}
+	}
+
+	for file := range affected {
+		parser.removeRoutes(file)
+	}
+
+	infos := make([]*AstFileInfo, 0, len(affected))
+	for _, info := range affected {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Path < infos[j].Path
+	})
+
+	for _, info := range infos {
+		operations, err := parser.parseFileOperations(info)
+		if err != nil {
+			return false, err
+		}
+
+		for _, operation := range operations {
+			for _, route := range operation.RouterProperties {
+				// the route is declared by another file too
+				item := parser.swagger.Paths.Paths[route.Path]
+				if *refRouteMethodOp(&item, route.HTTPMethod) != nil {
+					return false, nil
+				}
+			}
+		}
+
+		if err := parser.addOperations(info, operations); err != nil {
+			return false, err
+		}
+	}
+
+	if err := parser.checkOperationIDUniqueness(); err != nil {
+		return false, err
+	}
+
+	if err := parser.checkOperationLinks(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// forgetSchemas forgets the schemas of the invalid types and what the affected files and the invalid types used,
+// along with the definitions no longer referred to, so the operations parsed again add the ones they use.
+func (parser *Parser) forgetSchemas(invalid map[*TypeSpecDef]bool, affected map[*ast.File]*AstFileInfo) {
+	for typeSpecDef := range invalid {
+		delete(parser.parsedSchemas, typeSpecDef)
+		delete(parser.schemaDependents, typeSpecDef)
+
+		if schema, ok := parser.outputSchemas[typeSpecDef]; ok {
+			delete(parser.swagger.Definitions, schema.Name)
+			delete(parser.outputSchemas, typeSpecDef)
+		}
+
+		// the instances of generic types are instantiated again
+		if typeSpecDef.TypeSpec != nil && ignoreNameOverride(typeSpecDef.TypeSpec.Name.Name) {
+			delete(parser.packages.uniqueDefinitions, typeSpecDef.TypeSpec.Name.Name)
+		}
+	}
+
+	stack := parser.structStack[:0]
+	for _, typeSpecDef := range parser.structStack {
+		if !invalid[typeSpecDef] {
+			stack = append(stack, typeSpecDef)
+		}
+	}
+
+	parser.structStack = stack
+
+	for typeSpecDef, dependents := range parser.schemaDependents {
+		referred := false
+
+		for dependent, ref := range dependents {
+			if _, ok := affected[dependent.file]; ok && dependent.file != nil || invalid[dependent.typeSpecDef] {
+				delete(dependents, dependent)
+
+				continue
+			}
+
+			referred = referred || ref
+		}
+
+		if schema, ok := parser.outputSchemas[typeSpecDef]; ok && !referred {
+			delete(parser.swagger.Definitions, schema.Name)
+			delete(parser.outputSchemas, typeSpecDef)
+		}
+	}
+}
+
+// replaceFileTypes points the types declared by old to their declarations in the file of info, reporting false
+// if it doesn't declare the same types.
+func (parser *Parser) replaceFileTypes(old *ast.File, info *AstFileInfo) bool {
+	declared := make(map[string]*TypeSpecDef)
+
+	for _, typeSpecDef := range parser.packages.uniqueDefinitions {
+		if typeSpecDef.File == old && typeSpecDef.ParentSpec == nil && !ignoreNameOverride(typeSpecDef.TypeSpec.Name.Name) {
+			declared[typeSpecDef.Name()] = typeSpecDef
+		}
+	}
+
+	types := collectFileTypes(info.File, info.PackagePath)
+	if len(types.types) != len(declared) {
+		return false
+	}
+
+	for _, declaration := range types.types {
+		typeSpecDef, ok := declared[declaration.Name()]
+		if !ok {
+			return false
+		}
+
+		typeSpecDef.File = info.File
+		typeSpecDef.TypeSpec = declaration.TypeSpec
+		typeSpecDef.SetSchemaName()
+
+		// like ParseTypes, the types with enums are parsed
+		if schema := primitiveTypeSchema(typeSpecDef); schema != nil && typeSpecDef.Enums == nil {
+			parser.parsedSchemas[typeSpecDef] = schema
+		}
+	}
+
+	// the constants are the same, only their file changed
+	if pkg, ok := parser.packages.packages[info.PackagePath]; ok {
+		for _, constVar := range pkg.ConstTable {
+			if constVar.File == old {
+				constVar.File = info.File
+			}
+		}
+	}
+
+	return true
+}
+
+// removeRoutes removes the operations of file from the paths.
+func (parser *Parser) removeRoutes(file *ast.File) {
+	for _, route := range parser.fileRoutes[file] {
+		item, ok := parser.swagger.Paths.Paths[route.Path]
+		if !ok {
+			continue
+		}
+
+		*refRouteMethodOp(&item, route.HTTPMethod) = nil
+
+		parser.swagger.Paths.Paths[route.Path] = item
+
+		empty := true
+		for _, method := range sortedMethods() {
+			if *refRouteMethodOp(&item, method) != nil {
+				empty = false
+			}
+		}
+
+		if empty {
+			delete(parser.swagger.Paths.Paths, route.Path)
+		}
+	}
+
+	delete(parser.fileRoutes, file)
+}
+
+// sameDeclarations reports whether the files of old and info declare the same types and constants, and no
+// function scoped types, so the names of the types and the enums are the same.
+func sameDeclarations(old, info *AstFileInfo) bool {
+	oldTypes, oldConsts, ok := fileDeclarations(old.File)
+	if !ok {
+		return false
+	}
+
+	types, consts, ok := fileDeclarations(info.File)
+
+	return ok && slices.Equal(oldTypes, types) && oldConsts == consts
+}
+
+// fileDeclarations returns the sorted names of the types declared by file and its constants, reporting false
+// if it declares function scoped types.
+func fileDeclarations(file *ast.File) ([]string, string, bool) {
+	declarations := collectFileTypes(file, "")
+	if len(declarations.functionScopedTypes) > 0 {
+		return nil, "", false
+	}
+
+	names := make([]string, 0, len(declarations.types))
+	for _, typeSpecDef := range declarations.types {
+		names = append(names, typeSpecDef.Name())
+	}
+
+	sort.Strings(names)
+
+	var consts strings.Builder
+
+	for _, decl := range declarations.consts {
+		// the values of the constants without any are the ones of the previous constant, see collectConstVariables
+		var last *ast.ValueSpec
+
+		for _, astSpec := range decl.Specs {
+			valueSpec, ok := astSpec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+
+			valueType, values := valueSpec.Type, valueSpec.Values
+			if len(valueSpec.Names) == 1 && len(values) == 1 {
+				last = valueSpec
+			} else if len(valueSpec.Names) == 1 && len(values) == 0 && valueType == nil && last != nil {
+				valueType, values = last.Type, last.Values
+			}
+
+			for _, name := range valueSpec.Names {
+				consts.WriteString(name.Name + " ")
+			}
+
+			if valueType != nil {
+				consts.WriteString(types.ExprString(valueType))
+			}
+
+			for _, value := range values {
+				consts.WriteString(" = " + types.ExprString(value))
+			}
+
+			for _, group := range []*ast.CommentGroup{valueSpec.Doc, valueSpec.Comment} {
+				if group != nil {
+					for _, comment := range group.List {
+						consts.WriteString(" " + comment.Text)
+					}
+				}
+			}
+
+			consts.WriteString("\n")
+		}
+
+		consts.WriteString("\n")
+	}
+
+	return names, consts.String(), true
+}
+
+// changedTypes returns the names of the types whose declarations differ between the files of old and info, all of
+// them if the imports of the files differ.
+func changedTypes(old, info *AstFileInfo) map[string]bool {
+	oldDeclarations, declarations := typeDeclarations(old), typeDeclarations(info)
+
+	changed := make(map[string]bool)
+
+	for name, declaration := range declarations {
+		if oldDeclarations[name] != declaration {
+			changed[name] = true
+		}
+	}
+
+	return changed
+}
+
+// isChangedType reports whether typeSpecDef is one of the changed types, the generic instantiations of a file being
+// changed if any of its types is.
+func isChangedType(typeSpecDef *TypeSpecDef, changed map[string]bool) bool {
+	if ignoreNameOverride(typeSpecDef.Name()) {
+		return len(changed) > 0
+	}
+
+	return changed[typeSpecDef.Name()]
+}
+
+// typeDeclarations returns the source of the declaration of every type of the file of info, with its comments and
+// preceded by the imports of the file.
+func typeDeclarations(info *AstFileInfo) map[string]string {
+	var imports strings.Builder
+
+	for _, importSpec := range info.File.Imports {
+		if importSpec.Name != nil {
+			imports.WriteString(importSpec.Name.Name + " ")
+		}
+
+		imports.WriteString(importSpec.Path.Value + "\n")
+	}
+
+	declarations := make(map[string]string)
+
+	for _, decl := range info.File.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		var source strings.Builder
+
+		source.WriteString(imports.String())
+
+		err := printer.Fprint(&source, info.FileSet, &printer.CommentedNode{Node: genDecl, Comments: info.File.Comments})
+		if err != nil {
+			// never the same, so the types are parsed again
+			source.WriteString(err.Error() + info.Path)
+		}
+
+		for _, astSpec := range genDecl.Specs {
+			if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
+				declarations[typeSpec.Name.Name] = source.String()
+			}
+		}
+	}
+
+	return declarations
+}
+
+// declaresSharedComments reports whether file declares operation defaults, webhooks or callbacks, which
+// the operations of the other files use.
+func declaresSharedComments(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if isOperationDefaultsComment(group.List) || isWebhookOrCallbackComment(group.List) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// funcDetailComments returns the comments built from the gin handlers of each file, by path.
+func funcDetailComments(fdList []*gin.FileDetail) map[string]string {
+	comments := make(map[string]string)
+
+	for _, fileDetail := range fdList {
+		var builder strings.Builder
+
+		for _, funcDetail := range fileDetail.FuncDetailList {
+			builder.WriteString(funcDetail.FuncName)
+
+			for _, comment := range funcDetail.BuildComment() {
+				builder.WriteString("\n" + comment.Text)
+			}
+
+			builder.WriteString("\n\n")
+		}
+
+		comments[fileDetail.Filename] += builder.String()
+	}
+
+	return comments
+}
+
+// changedFuncDetails returns the paths of the files whose comments built from the gin handlers changed.
+func changedFuncDetails(previous, current map[string]string) []string {
+	var paths []string
+
+	for path, comments := range current {
+		if previous[path] != comments {
+			paths = append(paths, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// searchDirPackage returns the package path getAllGoFileInfo gives to the file of path,
+// or an empty one if it isn't in one of searchDirs or is skipped.
+func (parser *Parser) searchDirPackage(searchDirs []string, path string) (string, error) {
+	for _, searchDir := range searchDirs {
+		relPath, err := filepath.Rel(searchDir, path)
+		if err != nil || strings.HasPrefix(relPath, "..") {
+			continue
+		}
+
+		dir := searchDir
+		for _, name := range strings.Split(filepath.Dir(relPath), string(filepath.Separator)) {
+			if name == "." {
+				continue
+			}
+
+			dir = filepath.Join(dir, name)
+
+			f, err := os.Stat(dir)
+			if err != nil {
+				return "", err
+			}
+
+			if parser.Skip(dir, f) != nil {
+				return "", nil
+			}
+		}
+
+		pkgPath, err := parser.filePackagePath(parser.searchDirPackagePath(searchDir), searchDir, path)
+		if err != nil || parser.skipPackageByPrefix(pkgPath) {
+			return "", err
+		}
+
+		return pkgPath, nil
+	}
+
+	return "", nil
+}
+
+// isGoSourceFile reports whether path is a Go file parseFile parses.
+func isGoSourceFile(path string) bool {
+	return !strings.HasSuffix(strings.ToLower(path), "_test.go") && filepath.Ext(path) == ".go"
+}
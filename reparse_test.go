@@ -0,0 +1,242 @@
+package swag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Reparse(t *testing.T) {
+	searchDir, err := os.MkdirTemp("testdata", "reparse")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(searchDir)
+
+	mainFile := filepath.Join(searchDir, "main.go")
+	ordersFile := filepath.Join(searchDir, "orders.go")
+
+	require.NoError(t, os.WriteFile(mainFile, []byte(`package main
+
+type User struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+// @title Swagger Example API
+// @version 1.0
+func main() {}
+
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+`), 0644))
+
+	p := New()
+	require.NoError(t, p.ParseAPIMultiSearchDir([]string{searchDir}, "main.go", 100))
+	assert.Len(t, p.swagger.Paths.Paths, 1)
+
+	modTimes, err := p.GoFileModTimes([]string{searchDir})
+	require.NoError(t, err)
+	assert.Contains(t, modTimes, mainFile)
+
+	require.NoError(t, os.WriteFile(mainFile, []byte(`package main
+
+type User struct {
+	Name  string `+"`json:\"name\"`"+`
+	Email string `+"`json:\"email\"`"+`
+}
+
+// @title Swagger Example API
+// @version 2.0
+func main() {}
+
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+`), 0644))
+	require.NoError(t, os.WriteFile(ordersFile, []byte(`package main
+
+type Order struct {
+	ID int `+"`json:\"id\"`"+`
+}
+
+// @Success 200 {array} Order
+// @Router /orders [get]
+func ListOrders() {}
+`), 0644))
+
+	require.NoError(t, p.Reparse([]string{searchDir}, "main.go", []string{mainFile, ordersFile}))
+	assert.Equal(t, "2.0", p.swagger.Info.Version)
+	assert.Len(t, p.swagger.Paths.Paths, 2)
+	assert.Contains(t, p.swagger.Definitions, "main.Order")
+	assert.Contains(t, p.swagger.Definitions["main.User"].Properties, "email")
+
+	require.NoError(t, os.Remove(ordersFile))
+
+	require.NoError(t, p.Reparse([]string{searchDir}, "main.go", []string{ordersFile}))
+	assert.Len(t, p.swagger.Paths.Paths, 1)
+	assert.NotContains(t, p.swagger.Definitions, "main.Order")
+	assert.Len(t, p.packages.files, 1)
+}
+
+func TestParser_ReparseChanged(t *testing.T) {
+	searchDir, err := os.MkdirTemp("testdata", "reparse")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(searchDir)
+
+	write := func(name, src string) string {
+		path := filepath.Join(searchDir, name)
+		require.NoError(t, os.WriteFile(path, []byte("package main\n"+src), 0644))
+
+		return path
+	}
+
+	write("main.go", `
+// @title Swagger Example API
+// @version 1.0
+func main() {}
+`)
+	modelsFile := write("models.go", `
+type User struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+type Order struct {
+	ID    int  `+"`json:\"id\"`"+`
+	Buyer User `+"`json:\"buyer\"`"+`
+}
+
+type Stock struct {
+	Count int `+"`json:\"count\"`"+`
+}
+`)
+	usersFile := write("users.go", `
+// @Summary get a user
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+`)
+	write("orders.go", `
+// @Success 200 {array} Order
+// @Router /orders [get]
+func ListOrders() {}
+`)
+	stockFile := write("stock.go", `
+// @Success 200 {object} Stock
+// @Router /stock [get]
+func GetStock() {}
+`)
+
+	p := New()
+	require.NoError(t, p.ParseAPIMultiSearchDir([]string{searchDir}, "main.go", 100))
+
+	reparse := func(paths ...string) {
+		require.NoError(t, p.Reparse([]string{searchDir}, "main.go", paths))
+
+		expected := New()
+		require.NoError(t, expected.ParseAPIMultiSearchDir([]string{searchDir}, "main.go", 100))
+
+		expectedDoc, err := json.Marshal(expected.GetSwagger())
+		require.NoError(t, err)
+
+		doc, err := json.Marshal(p.GetSwagger())
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expectedDoc), string(doc))
+	}
+
+	operations := func() (user, orders, stock *spec.Operation) {
+		return p.swagger.Paths.Paths["/users/{id}"].Get, p.swagger.Paths.Paths["/orders"].Get,
+			p.swagger.Paths.Paths["/stock"].Get
+	}
+
+	// the operations using the changed type, directly or through another type, are built again
+	user, orders, stock := operations()
+
+	write("models.go", `
+type User struct {
+	Name  string `+"`json:\"name\"`"+`
+	Email string `+"`json:\"email\"`"+`
+}
+
+type Order struct {
+	ID    int  `+"`json:\"id\"`"+`
+	Buyer User `+"`json:\"buyer\"`"+`
+}
+
+type Stock struct {
+	Count int `+"`json:\"count\"`"+`
+}
+`)
+	reparse(modelsFile)
+	assert.Contains(t, p.swagger.Definitions["main.User"].Properties, "email")
+
+	newUser, newOrders, newStock := operations()
+	assert.NotSame(t, user, newUser)
+	assert.NotSame(t, orders, newOrders)
+	assert.Same(t, stock, newStock)
+
+	// only the operations of a changed file without types are
+	user, orders, stock = operations()
+
+	write("users.go", `
+// @Summary get a user by id
+// @Success 200 {object} Stock
+// @Router /users/{id} [get]
+func GetUser() {}
+`)
+	reparse(usersFile)
+	assert.Equal(t, "get a user by id", p.swagger.Paths.Paths["/users/{id}"].Get.Summary)
+
+	newUser, newOrders, newStock = operations()
+	assert.NotSame(t, user, newUser)
+	assert.Same(t, orders, newOrders)
+	assert.Same(t, stock, newStock)
+
+	// the definitions no longer used are removed
+	write("stock.go", `
+// @Success 200 {object} Stock
+// @Router /stocks [get]
+func GetStock() {}
+`)
+	reparse(stockFile, usersFile)
+	assert.NotContains(t, p.swagger.Paths.Paths, "/stock")
+
+	write("users.go", `
+// @Summary get a user by id
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+`)
+	reparse(usersFile)
+
+	// a new type can change the names of the others, the whole document is built again
+	_, orders, _ = operations()
+
+	write("models.go", `
+type User struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+type Order struct {
+	ID    int  `+"`json:\"id\"`"+`
+	Buyer User `+"`json:\"buyer\"`"+`
+}
+
+type Stock struct {
+	Count int `+"`json:\"count\"`"+`
+}
+
+type Item struct {
+	Name string `+"`json:\"name\"`"+`
+}
+`)
+	reparse(modelsFile)
+
+	_, newOrders, _ = operations()
+	assert.NotSame(t, orders, newOrders)
+}
@@ -0,0 +1,111 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const serversExtension = "x-servers"
+
+// Server describes an OpenAPI 3 server object. Swagger 2.0 has no equivalent, so
+// servers are emitted in the x-servers extension of operations and tags.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// parseServer parses `url ["description"]`.
+func parseServer(attribute, commentLine string) (Server, error) {
+	fields := FieldsByAnySpace(strings.TrimSpace(commentLine), 2)
+	if len(fields) == 0 {
+		return Server{}, fmt.Errorf("%s needs a server url", attribute)
+	}
+
+	server := Server{URL: fields[0]}
+	if len(fields) > 1 {
+		server.Description = strings.Trim(strings.TrimSpace(fields[1]), "\"")
+	}
+
+	return server, nil
+}
+
+// addServer appends server to the x-servers extension of extensions.
+func addServer(extensions spec.Extensions, server Server) spec.Extensions {
+	if extensions == nil {
+		extensions = make(spec.Extensions)
+	}
+
+	servers, _ := extensions[serversExtension].([]Server)
+	extensions[serversExtension] = append(servers, server)
+
+	return extensions
+}
+
+// ParseServerComment parses comment for given `server` comment string,
+// eg: @Server https://upload.example.com/v1 "Upload server".
+func (operation *Operation) ParseServerComment(attribute, commentLine string) error {
+	server, err := parseServer(attribute, commentLine)
+	if err != nil {
+		return err
+	}
+
+	operation.Extensions = addServer(operation.Extensions, server)
+
+	return nil
+}
+
+// ParseHostComment parses comment for given `host` comment string, eg: @Host ws.example.com.
+// The host is combined with the general schemes and base path into a server.
+func (operation *Operation) ParseHostComment(commentLine string) error {
+	host := strings.TrimSpace(commentLine)
+	if host == "" {
+		return fmt.Errorf("%s needs a host", hostAttr)
+	}
+
+	var (
+		basePath string
+		schemes  []string
+	)
+
+	if operation.parser != nil {
+		basePath = operation.parser.swagger.BasePath
+		schemes = operation.parser.swagger.Schemes
+	}
+
+	if len(schemes) == 0 {
+		// scheme relative url
+		operation.Extensions = addServer(operation.Extensions, Server{URL: "//" + host + basePath})
+
+		return nil
+	}
+
+	for _, scheme := range schemes {
+		operation.Extensions = addServer(operation.Extensions, Server{URL: scheme + "://" + host + basePath})
+	}
+
+	return nil
+}
+
+// applyTagServers sets the servers of the first tag of operation declaring some,
+// if operation has no servers of its own.
+func (parser *Parser) applyTagServers(operation *Operation) {
+	if _, ok := operation.Extensions[serversExtension]; ok {
+		return
+	}
+
+	for _, tagName := range operation.Tags {
+		for _, tag := range parser.swagger.Tags {
+			if tag.Name != tagName {
+				continue
+			}
+
+			if servers, ok := tag.Extensions[serversExtension]; ok {
+				operation.Extensions[serversExtension] = servers
+
+				return
+			}
+		}
+	}
+}
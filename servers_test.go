@@ -0,0 +1,86 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServerComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Server https://upload.example.com/v1 "Upload server"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Servers wss://ws.example.com`, nil))
+	assert.Equal(t, []Server{
+		{URL: "https://upload.example.com/v1", Description: "Upload server"},
+		{URL: "wss://ws.example.com"},
+	}, operation.Extensions[serversExtension])
+
+	b, _ := json.Marshal(operation)
+	assert.JSONEq(t, `{"responses":{},"x-servers":[{"url":"https://upload.example.com/v1","description":"Upload server"},{"url":"wss://ws.example.com"}]}`, string(b))
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Server`, nil))
+}
+
+func TestParseHostComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Host upload.example.com`, nil))
+	assert.Equal(t, []Server{{URL: "//upload.example.com"}}, operation.Extensions[serversExtension])
+
+	parser := New()
+	parser.swagger.BasePath = "/v1"
+	parser.swagger.Schemes = []string{"http", "https"}
+
+	operation = NewOperation(parser)
+	assert.NoError(t, operation.ParseComment(`/@Host upload.example.com`, nil))
+	assert.Equal(t, []Server{
+		{URL: "http://upload.example.com/v1"},
+		{URL: "https://upload.example.com/v1"},
+	}, operation.Extensions[serversExtension])
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Host`, nil))
+}
+
+func TestParser_TagServers(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.NoError(t, parseGeneralAPIInfo(p, []string{
+		"@tag.name upload",
+		`@tag.server https://upload.example.com "Upload server"`,
+		"@tag.name other",
+	}))
+	assert.Equal(t, []Server{{URL: "https://upload.example.com", Description: "Upload server"}}, p.swagger.Tags[0].Extensions[serversExtension])
+	assert.Nil(t, p.swagger.Tags[1].Extensions)
+
+	src := `
+package api
+
+// @Tags upload
+// @Router /upload [post]
+func Upload(){
+}
+
+// @Tags upload
+// @Server https://cdn.example.com
+// @Router /upload/cdn [post]
+func UploadCDN(){
+}
+
+// @Tags other
+// @Router /other [get]
+func Other(){
+}
+`
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	ps := p.swagger.Paths.Paths
+	assert.Equal(t, []Server{{URL: "https://upload.example.com", Description: "Upload server"}}, ps["/upload"].Post.Extensions[serversExtension])
+	assert.Equal(t, []Server{{URL: "https://cdn.example.com"}}, ps["/upload/cdn"].Post.Extensions[serversExtension])
+	assert.NotContains(t, ps["/other"].Get.Extensions, serversExtension)
+}
@@ -3,11 +3,25 @@ package swag
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"strings"
+	"sync"
 	"text/template"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
 )
 
 // Spec holds exported Swagger Info so clients can modify it.
+//
+// The exported fields are rendered into SwaggerTemplate on each ReadDoc call until the
+// document is accessed through one of the structured methods (Update, AddPath, Swagger, JSON, ...).
+// From then on the document is parsed once and kept as a spec.Swagger, changes to the
+// exported fields are ignored and the document can only be changed through those methods.
+// The serialized JSON and YAML are cached until the next change.
+//
+// The methods of a Spec are safe for concurrent use, the exported fields must be set before the
+// document is read concurrently. A Spec must not be copied after first use.
 type Spec struct {
 	Version          string
 	Host             string
@@ -19,10 +33,45 @@ type Spec struct {
 	SwaggerTemplate  string
 	LeftDelim        string
 	RightDelim       string
+
+	mu   sync.RWMutex
+	doc  *spec.Swagger
+	json []byte
+	yaml []byte
 }
 
 // ReadDoc parses SwaggerTemplate into swagger document.
 func (i *Spec) ReadDoc() string {
+	i.mu.RLock()
+	loaded := i.doc != nil
+	i.mu.RUnlock()
+
+	if loaded {
+		doc, err := i.cachedJSON()
+		if err != nil {
+			return ""
+		}
+
+		return string(doc)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.doc != nil {
+		doc, err := i.marshalJSON()
+		if err != nil {
+			return ""
+		}
+
+		return string(doc)
+	}
+
+	return i.renderTemplate()
+}
+
+// renderTemplate renders SwaggerTemplate with the exported fields, it must be called with i.mu held.
+func (i *Spec) renderTemplate() string {
 	i.Description = strings.ReplaceAll(i.Description, "\n", "\\n")
 
 	tpl := template.New("swagger_info").Funcs(template.FuncMap{
@@ -58,7 +107,312 @@ func (i *Spec) ReadDoc() string {
 	return doc.String()
 }
 
+// newSpecView returns a Spec holding doc, derived from the document of another Spec, named instanceName.
+func newSpecView(doc *spec.Swagger, instanceName string) *Spec {
+	view := &Spec{
+		Host:             doc.Host,
+		BasePath:         doc.BasePath,
+		Schemes:          doc.Schemes,
+		InfoInstanceName: instanceName,
+		doc:              doc,
+	}
+
+	if doc.Info != nil {
+		view.Version = doc.Info.Version
+		view.Title = doc.Info.Title
+		view.Description = doc.Info.Description
+	}
+
+	return view
+}
+
+// Must returns s, and panics if err is not nil. The generated docs.go files use it to derive
+// documents when their package is initialized, eg: swag.Must(SwaggerInfo.VersionView("v1", "/api/v1")).
+func Must(s *Spec, err error) *Spec {
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
 // InstanceName returns Spec instance name.
 func (i *Spec) InstanceName() string {
 	return i.InfoInstanceName
 }
+
+// load parses the rendered template into the structured document, it must be called with i.mu held.
+func (i *Spec) load() error {
+	if i.doc != nil {
+		return nil
+	}
+
+	var doc spec.Swagger
+
+	err := json.Unmarshal([]byte(i.renderTemplate()), &doc)
+	if err != nil {
+		return err
+	}
+
+	i.doc = &doc
+
+	return nil
+}
+
+// Update calls fn with the structured document under write lock, the cached JSON
+// and YAML are rebuilt on next read. The document must not be retained after fn returns.
+func (i *Spec) Update(fn func(doc *spec.Swagger) error) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	err := i.load()
+	if err != nil {
+		return err
+	}
+
+	i.json, i.yaml = nil, nil
+
+	return fn(i.doc)
+}
+
+// Swagger returns a copy of the structured document.
+func (i *Spec) Swagger() (*spec.Swagger, error) {
+	doc, err := i.cachedJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var swagger spec.Swagger
+
+	err = json.Unmarshal(doc, &swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &swagger, nil
+}
+
+// JSON returns a copy of the document serialized as JSON.
+func (i *Spec) JSON() ([]byte, error) {
+	doc, err := i.cachedJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(doc), nil
+}
+
+// cachedJSON returns the cached JSON, which must not be modified.
+func (i *Spec) cachedJSON() ([]byte, error) {
+	i.mu.RLock()
+	doc := i.json
+	i.mu.RUnlock()
+
+	if doc != nil {
+		return doc, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.marshalJSON()
+}
+
+// YAML returns a copy of the document serialized as YAML.
+func (i *Spec) YAML() ([]byte, error) {
+	doc, err := i.cachedYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(doc), nil
+}
+
+// cachedYAML returns the cached YAML, which must not be modified.
+func (i *Spec) cachedYAML() ([]byte, error) {
+	i.mu.RLock()
+	doc := i.yaml
+	i.mu.RUnlock()
+
+	if doc != nil {
+		return doc, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.yaml != nil {
+		return i.yaml, nil
+	}
+
+	doc, err := i.marshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	i.yaml, err = yaml.JSONToYAML(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.yaml, nil
+}
+
+// marshalJSON returns the cached JSON, it must be called with i.mu held.
+func (i *Spec) marshalJSON() ([]byte, error) {
+	if i.json != nil {
+		return i.json, nil
+	}
+
+	err := i.load()
+	if err != nil {
+		return nil, err
+	}
+
+	i.json, err = json.MarshalIndent(i.doc, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+
+	return i.json, nil
+}
+
+// AddPath adds or replaces the path item of given path.
+func (i *Spec) AddPath(path string, item spec.PathItem) error {
+	return i.Update(func(doc *spec.Swagger) error {
+		if doc.Paths == nil {
+			doc.Paths = &spec.Paths{}
+		}
+
+		if doc.Paths.Paths == nil {
+			doc.Paths.Paths = make(map[string]spec.PathItem)
+		}
+
+		doc.Paths.Paths[path] = item
+
+		return nil
+	})
+}
+
+// RemovePath removes given paths from the document.
+func (i *Spec) RemovePath(paths ...string) error {
+	return i.Update(func(doc *spec.Swagger) error {
+		if doc.Paths == nil {
+			return nil
+		}
+
+		for _, path := range paths {
+			delete(doc.Paths.Paths, path)
+		}
+
+		return nil
+	})
+}
+
+// SetSecurityDefinition adds or replaces the security definition of given name.
+func (i *Spec) SetSecurityDefinition(name string, scheme *spec.SecurityScheme) error {
+	if scheme == nil {
+		return errors.New("security scheme is nil")
+	}
+
+	return i.Update(func(doc *spec.Swagger) error {
+		if doc.SecurityDefinitions == nil {
+			doc.SecurityDefinitions = make(spec.SecurityDefinitions)
+		}
+
+		doc.SecurityDefinitions[name] = scheme
+
+		return nil
+	})
+}
+
+// SetSecurity sets the security requirements applied to all operations.
+func (i *Spec) SetSecurity(security ...map[string][]string) error {
+	return i.Update(func(doc *spec.Swagger) error {
+		doc.Security = security
+
+		return nil
+	})
+}
+
+// AddExtension adds or replaces a top level vendor extension, eg: x-servers.
+func (i *Spec) AddExtension(key string, value interface{}) error {
+	return i.Update(func(doc *spec.Swagger) error {
+		doc.AddExtension(key, value)
+
+		return nil
+	})
+}
+
+// FilterByTag keeps only the operations tagged with at least one of given tags,
+// paths without operations left and tags not in the list are removed.
+func (i *Spec) FilterByTag(tags ...string) error {
+	keep := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		keep[tag] = struct{}{}
+	}
+
+	return i.Update(func(doc *spec.Swagger) error {
+		filterOperations(doc, func(op *spec.Operation) bool {
+			for _, tag := range op.Tags {
+				if _, ok := keep[tag]; ok {
+					return true
+				}
+			}
+
+			return false
+		})
+
+		var docTags []spec.Tag
+
+		for _, tag := range doc.Tags {
+			if _, ok := keep[tag.Name]; ok {
+				docTags = append(docTags, tag)
+			}
+		}
+
+		doc.Tags = docTags
+
+		return nil
+	})
+}
+
+// filterOperations removes the operations for which keep returns false, and the paths without operations left.
+func filterOperations(doc *spec.Swagger, keep func(op *spec.Operation) bool) {
+	if doc.Paths == nil {
+		return
+	}
+
+	filterPathItems(doc.Paths.Paths, keep)
+}
+
+// filterPathItems removes the operations of items for which keep returns false, and the items without
+// operations left.
+func filterPathItems(items map[string]spec.PathItem, keep func(op *spec.Operation) bool) {
+	for path, item := range items {
+		empty := true
+
+		for method := range allMethod {
+			op := refRouteMethodOp(&item, method)
+			if *op == nil {
+				continue
+			}
+
+			if !keep(*op) {
+				*op = nil
+
+				continue
+			}
+
+			empty = false
+		}
+
+		if empty {
+			delete(items, path)
+
+			continue
+		}
+
+		items[path] = item
+	}
+}
@@ -1,8 +1,10 @@
 package swag
 
 import (
+	"sync"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -186,3 +188,148 @@ func TestSpec_ReadDoc(t *testing.T) {
 		})
 	}
 }
+
+const testSpecTemplate = `{
+    "swagger": "2.0",
+    "info": {
+        "title": "{{.Title}}",
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "paths": {
+        "/pets": {
+            "get": {"tags": ["pet"], "responses": {"200": {"description": "OK"}}},
+            "post": {"tags": ["admin"], "responses": {"201": {"description": "Created"}}}
+        },
+        "/internal/metrics": {
+            "get": {"tags": ["internal"], "responses": {"200": {"description": "OK"}}}
+        }
+    },
+    "tags": [{"name": "pet"}, {"name": "admin"}, {"name": "internal"}]
+}`
+
+func TestSpec_Update(t *testing.T) {
+	t.Parallel()
+
+	doc := &Spec{
+		Version:         "1.0",
+		Host:            "localhost:8080",
+		Title:           "Pets",
+		SwaggerTemplate: testSpecTemplate,
+	}
+
+	assert.NoError(t, doc.SetSecurityDefinition("ApiKey", spec.APIKeyAuth("X-API-Key", "header")))
+	assert.NoError(t, doc.SetSecurity(map[string][]string{"ApiKey": {}}))
+	assert.NoError(t, doc.AddExtension("x-servers", []Server{{URL: "https://pets.example.com"}}))
+	assert.NoError(t, doc.AddPath("/health", spec.PathItem{PathItemProps: spec.PathItemProps{Get: spec.NewOperation("health")}}))
+	assert.NoError(t, doc.RemovePath("/internal/metrics"))
+	assert.NoError(t, doc.Update(func(doc *spec.Swagger) error {
+		doc.Info.Description = "tenant a"
+
+		return nil
+	}))
+
+	// the exported fields are ignored once the document is parsed.
+	doc.Host = "ignored"
+
+	swagger, err := doc.Swagger()
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:8080", swagger.Host)
+	assert.Equal(t, "tenant a", swagger.Info.Description)
+	assert.Equal(t, "header", swagger.SecurityDefinitions["ApiKey"].In)
+	assert.Equal(t, []map[string][]string{{"ApiKey": {}}}, swagger.Security)
+	assert.Contains(t, swagger.Extensions, "x-servers")
+	assert.Contains(t, swagger.Paths.Paths, "/health")
+	assert.NotContains(t, swagger.Paths.Paths, "/internal/metrics")
+
+	// Swagger returns a copy.
+	swagger.Host = "changed"
+	assert.Contains(t, doc.ReadDoc(), `"host": "localhost:8080"`)
+
+	yml, err := doc.YAML()
+	assert.NoError(t, err)
+	assert.Contains(t, string(yml), "host: localhost:8080")
+}
+
+func TestSpec_FilterByTag(t *testing.T) {
+	t.Parallel()
+
+	doc := &Spec{SwaggerTemplate: testSpecTemplate}
+	assert.NoError(t, doc.FilterByTag("pet"))
+
+	swagger, err := doc.Swagger()
+	assert.NoError(t, err)
+	assert.Len(t, swagger.Paths.Paths, 1)
+	assert.NotNil(t, swagger.Paths.Paths["/pets"].Get)
+	assert.Nil(t, swagger.Paths.Paths["/pets"].Post)
+	assert.Equal(t, []spec.Tag{{TagProps: spec.TagProps{Name: "pet"}}}, swagger.Tags)
+}
+
+func TestSpec_JSONCache(t *testing.T) {
+	t.Parallel()
+
+	doc := &Spec{SwaggerTemplate: testSpecTemplate}
+
+	first, err := doc.cachedJSON()
+	assert.NoError(t, err)
+
+	second, err := doc.cachedJSON()
+	assert.NoError(t, err)
+	assert.Same(t, &first[0], &second[0])
+
+	// the returned documents are copies of the cache
+	copied, err := doc.JSON()
+	assert.NoError(t, err)
+	assert.Equal(t, first, copied)
+
+	copied[0] = '['
+	assert.Equal(t, byte('{'), first[0])
+
+	copied, err = doc.YAML()
+	assert.NoError(t, err)
+
+	cached, err := doc.cachedYAML()
+	assert.NoError(t, err)
+	assert.NotSame(t, &cached[0], &copied[0])
+
+	assert.NoError(t, doc.RemovePath("/pets"))
+
+	third, err := doc.JSON()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(third), "/pets\"")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_ = doc.ReadDoc()
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_ = doc.AddExtension("x-tenant", "a")
+		}()
+	}
+	wg.Wait()
+
+	_, err = (&Spec{SwaggerTemplate: "{"}).JSON()
+	assert.Error(t, err)
+
+	// the template is rendered concurrently until the document is loaded
+	doc = &Spec{SwaggerTemplate: testSpecTemplate, Description: "line\nline"}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.Contains(t, doc.ReadDoc(), "swagger")
+		}()
+	}
+	wg.Wait()
+}
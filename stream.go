@@ -0,0 +1,131 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const streamExtension = "x-stream"
+
+var streamFormatAliases = map[string]string{
+	"sse":    "text/event-stream",
+	"ndjson": "application/x-ndjson",
+}
+
+// Stream describes the items of a streamed response. Swagger 2.0 has no equivalent,
+// so streams are emitted in the x-stream extension of responses.
+type Stream struct {
+	MediaType  string                 `json:"mediaType"`
+	ItemSchema *spec.Schema           `json:"itemSchema,omitempty"`
+	Events     map[string]spec.Schema `json:"events,omitempty"`
+}
+
+// @Stream 200 sse message=model.Message,done=model.Done "chat events".
+var streamPattern = regexp.MustCompile(`^([\w,]+)[[:blank:]]+(\S+)[[:blank:]]+([^"\s]\S*)(?:[[:blank:]]+"(.*)")?$`)
+
+// ParseStreamComment parses comment for given `stream` comment string, eg:
+// @Stream 200 ndjson model.Row "rows", or @Stream 200 sse message=model.Message,done=model.Done
+// to name the type of each server-sent event.
+func (operation *Operation) ParseStreamComment(commentLine string, astFile *ast.File) error {
+	matches := streamPattern.FindStringSubmatch(strings.TrimSpace(commentLine))
+	if len(matches) != 5 {
+		return fmt.Errorf("can not parse stream comment \"%s\"", commentLine)
+	}
+
+	mediaType, ok := streamFormatAliases[strings.ToLower(matches[2])]
+	if !ok {
+		if !mimeTypePattern.MatchString(matches[2]) {
+			return fmt.Errorf("%v stream type can't be accepted", matches[2])
+		}
+
+		mediaType = matches[2]
+	}
+
+	stream := Stream{MediaType: mediaType}
+
+	var schema *spec.Schema
+
+	if strings.Contains(matches[3], "=") {
+		stream.Events = make(map[string]spec.Schema)
+
+		for _, field := range parseFields(matches[3]) {
+			keyVal := strings.SplitN(field, "=", 2)
+			if len(keyVal) != 2 || keyVal[0] == "" {
+				return fmt.Errorf("stream event should format: name=type, got \"%s\"", field)
+			}
+
+			eventSchema, err := operation.parseObjectSchema(keyVal[1], astFile)
+			if err != nil {
+				return err
+			}
+
+			if eventSchema == nil {
+				eventSchema = &spec.Schema{}
+			}
+
+			stream.Events[keyVal[0]] = *eventSchema
+		}
+	} else {
+		itemSchema, err := operation.parseObjectSchema(matches[3], astFile)
+		if err != nil {
+			return err
+		}
+
+		stream.ItemSchema = itemSchema
+		schema = itemSchema
+	}
+
+	if !findInSlice(operation.Produces, mediaType) {
+		operation.Produces = append(operation.Produces, mediaType)
+	}
+
+	description := matches[4]
+
+	for _, codeStr := range strings.Split(matches[1], ",") {
+		if strings.EqualFold(codeStr, defaultTag) {
+			response := operation.DefaultResponse()
+			if description != "" {
+				response.WithDescription(description)
+			}
+
+			if schema != nil {
+				response.WithSchema(schema)
+			}
+
+			response.AddExtension(streamExtension, stream)
+
+			continue
+		}
+
+		code, err := strconv.Atoi(codeStr)
+		if err != nil {
+			return fmt.Errorf("can not parse stream comment \"%s\"", commentLine)
+		}
+
+		response, ok := operation.Responses.StatusCodeResponses[code]
+		if !ok {
+			response = *spec.NewResponse().WithDescription(http.StatusText(code))
+		}
+
+		if description != "" {
+			response.WithDescription(description)
+		}
+
+		// the events of a stream keep the schema of the response set by @Success
+		if schema != nil {
+			response.WithSchema(schema)
+		}
+
+		response.AddExtension(streamExtension, stream)
+
+		operation.AddResponse(code, &response)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStreamComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Stream 200 ndjson string "rows"`, nil))
+	assert.Equal(t, []string{"application/x-ndjson"}, operation.Produces)
+
+	response := operation.Responses.StatusCodeResponses[200]
+	assert.Equal(t, Stream{
+		MediaType:  "application/x-ndjson",
+		ItemSchema: PrimitiveSchema(STRING),
+	}, response.Extensions[streamExtension])
+
+	b, _ := json.Marshal(response)
+	assert.JSONEq(t, `{
+		"description": "rows",
+		"schema": {"type": "string"},
+		"x-stream": {
+			"mediaType": "application/x-ndjson",
+			"itemSchema": {"type": "string"}
+		}
+	}`, string(b))
+
+	operation = NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Produce json`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Stream 200,default sse message=string,done=integer`, nil))
+	assert.Equal(t, []string{"application/json", "text/event-stream"}, operation.Produces)
+
+	response = operation.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "OK", response.Description)
+	assert.Nil(t, response.Schema)
+	assert.Equal(t, Stream{
+		MediaType: "text/event-stream",
+		Events: map[string]spec.Schema{
+			"message": *PrimitiveSchema(STRING),
+			"done":    *PrimitiveSchema(INTEGER),
+		},
+	}, response.Extensions[streamExtension])
+	assert.Equal(t, response.Extensions[streamExtension], operation.Responses.Default.Extensions[streamExtension])
+
+	operation = NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Success 200 {string} string "chunks"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Stream 200 application/octet-stream string`, nil))
+	assert.Equal(t, "chunks", operation.Responses.StatusCodeResponses[200].Description)
+	assert.Equal(t, "application/octet-stream", operation.Responses.StatusCodeResponses[200].Extensions[streamExtension].(Stream).MediaType)
+
+	operation = NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Success 200 {string} string "events"`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Stream 200 sse message=string`, nil))
+	assert.Equal(t, PrimitiveSchema(STRING), operation.Responses.StatusCodeResponses[200].Schema)
+	assert.Contains(t, operation.Responses.StatusCodeResponses[200].Extensions, streamExtension)
+}
+
+func TestParseStreamCommentErr(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Stream 200 sse`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Stream 200 unknown string`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Stream abc sse string`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Stream 200 sse =string`, nil))
+}
+
+func TestParser_ParseStream(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Message struct {
+	Text string
+}
+
+// @Stream 200 sse message=Message "chat events"
+// @Router /chat [get]
+func Chat(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	b, err := json.Marshal(p.swagger.Paths.Paths["/chat"].Get)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"produces": ["text/event-stream"],
+		"responses": {
+			"200": {
+				"description": "chat events",
+				"x-stream": {
+					"mediaType": "text/event-stream",
+					"events": {"message": {"$ref": "#/definitions/api.Message"}}
+				}
+			}
+		}
+	}`, string(b))
+	assert.Contains(t, p.swagger.Definitions, "api.Message")
+}
@@ -0,0 +1,26 @@
+package main
+
+import "net/http"
+
+// @title Swagger Example API
+// @version 1.0
+// @description Handlers and types guarded by build constraints.
+// @BasePath /v1
+func main() {
+	http.HandleFunc("/users", GetUser)
+	http.HandleFunc("/settings", GetSettings)
+}
+
+// GetUser returns a user.
+// @Summary Get a user
+// @Produce json
+// @Success 200 {object} User
+// @Router /users [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// GetSettings returns the settings.
+// @Summary Get the settings
+// @Produce json
+// @Success 200 {object} Settings
+// @Router /settings [get]
+func GetSettings(w http.ResponseWriter, r *http.Request) {}
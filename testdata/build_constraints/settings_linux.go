@@ -0,0 +1,6 @@
+package main
+
+// Settings are the settings of the server.
+type Settings struct {
+	Home string `json:"home"`
+}
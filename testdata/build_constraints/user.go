@@ -0,0 +1,8 @@
+//go:build !enterprise
+
+package main
+
+// User is a user.
+type User struct {
+	Name string `json:"name"`
+}
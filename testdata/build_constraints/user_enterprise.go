@@ -0,0 +1,18 @@
+//go:build enterprise
+
+package main
+
+import "net/http"
+
+// User is a user of an enterprise license.
+type User struct {
+	Name  string `json:"name"`
+	Seats int    `json:"seats"`
+}
+
+// GetLicense returns the license.
+// @Summary Get the enterprise license
+// @Produce json
+// @Success 200 {string} string
+// @Router /license [get]
+func GetLicense(w http.ResponseWriter, r *http.Request) {}
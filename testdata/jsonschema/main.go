@@ -0,0 +1,27 @@
+package main
+
+import "github.com/swaggo/swag/testdata/jsonschema/model"
+
+// User account.
+type User struct {
+	Name     string  `json:"name" binding:"required" example:"gopher"`
+	Nickname *string `json:"nickname" extensions:"x-nullable"`
+	Age      int     `json:"age" minimum:"0" maximum:"150"`
+}
+
+// @title Swagger Example API
+// @version 1.0
+// @BasePath /api
+func main() {}
+
+// GetUser godoc
+// @Summary Get a user
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// ListEvents godoc
+// @Summary List the events of a user
+// @Success 200 {array} model.Payload
+// @Router /users/{id}/events [get]
+func ListEvents() { _ = model.Event{} }
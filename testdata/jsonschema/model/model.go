@@ -0,0 +1,28 @@
+package model
+
+// Event published on the event pipeline.
+// @JSONSchema
+type Event struct {
+	ID      string   `json:"id" binding:"required"`
+	Level   Level    `json:"level"`
+	Payload *Payload `json:"payload" extensions:"x-nullable"`
+}
+
+// Payload of an event.
+type Payload struct {
+	Data map[string]string `json:"data"`
+}
+
+// Level of an event.
+// @JSONSchema
+type Level string
+
+// Config of a validator, only selected by name.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule of a validator.
+type Rule struct {
+	Pattern string `json:"pattern"`
+}
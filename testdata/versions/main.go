@@ -0,0 +1,29 @@
+package main
+
+// User account.
+type User struct {
+	Name string `json:"name"`
+}
+
+// Order of a user.
+type Order struct {
+	ID int `json:"id"`
+}
+
+// @title Swagger Example API
+// @version 1.0
+// @BasePath /api
+func main() {}
+
+// GetUser godoc
+// @Summary Get a user
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// ListOrders godoc
+// @Summary List the orders
+// @Version v2
+// @Success 200 {array} Order
+// @Router /orders [get]
+func ListOrders() {}
@@ -0,0 +1,31 @@
+package main
+
+// User account.
+type User struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required" visibility:"partner"`
+	Token string `json:"token" extensions:"x-internal"`
+}
+
+// Metrics of the service.
+type Metrics struct {
+	Requests int `json:"requests"`
+}
+
+// @title Swagger Example API
+// @version 1.0
+// @BasePath /api
+func main() {}
+
+// GetUser godoc
+// @Summary Get a user
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser() {}
+
+// GetMetrics godoc
+// @Summary Get metrics
+// @Visibility internal
+// @Success 200 {object} Metrics
+// @Router /metrics [get]
+func GetMetrics() {}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+
+	"example.com/workspace/models"
+)
+
+// @title Swagger Example API
+// @version 1.0
+// @description The API and the models are in two modules of a workspace.
+// @BasePath /v1
+func main() {
+	http.HandleFunc("/pets", GetPet)
+}
+
+// GetPet returns a pet.
+// @Summary Get a pet
+// @Produce json
+// @Success 200 {object} models.Pet
+// @Router /pets [get]
+func GetPet(w http.ResponseWriter, r *http.Request) {
+	_ = models.Pet{}
+}
@@ -0,0 +1,3 @@
+module example.com/workspace/models
+
+go 1.23.0
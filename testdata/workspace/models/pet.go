@@ -0,0 +1,10 @@
+package models
+
+import "example.com/workspace/models/tags"
+
+// Pet is a pet.
+type Pet struct {
+	ID   int        `json:"id"`
+	Name string     `json:"name"`
+	Tags []tags.Tag `json:"tags"`
+}
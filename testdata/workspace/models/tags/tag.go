@@ -0,0 +1,6 @@
+package tags
+
+// Tag is a tag of a pet.
+type Tag struct {
+	Name string `json:"name"`
+}
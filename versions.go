@@ -0,0 +1,106 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const versionsExtension = "x-versions"
+
+// ParseVersionComment parses comment for given `version` comment string, eg: @Version v1,v2.
+func (operation *Operation) ParseVersionComment(commentLine string) error {
+	var versions []interface{}
+
+	for _, version := range strings.Split(commentLine, ",") {
+		version = strings.TrimSpace(version)
+		if version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return fmt.Errorf("@Version needs at least a version, eg: @Version v1,v2")
+	}
+
+	operation.Operation.AddExtension(versionsExtension, versions)
+
+	return nil
+}
+
+// FilterVersion returns a copy of swagger with only the operations of given version, with basePath as base path
+// and version as info version. Operations without @Version belong to every version, the kept operations
+// keep their x-versions extension.
+// Definitions which are no longer reachable once filtered are removed.
+func FilterVersion(swagger *spec.Swagger, version, basePath string) (*spec.Swagger, error) {
+	doc, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered spec.Swagger
+
+	err = json.Unmarshal(doc, &filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable, err := reachableDefinitions(&filtered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	filterOperations(&filtered, func(op *spec.Operation) bool {
+		versions, ok := op.Extensions.GetStringSlice(versionsExtension)
+		if !ok {
+			return true
+		}
+
+		for _, v := range versions {
+			if v == version {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	stillReachable, err := reachableDefinitions(&filtered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range reachable {
+		if _, ok := stillReachable[name]; !ok {
+			delete(filtered.Definitions, name)
+		}
+	}
+
+	filtered.BasePath = basePath
+
+	if filtered.Info == nil {
+		filtered.Info = &spec.Info{}
+	}
+
+	filtered.Info.Version = version
+
+	return &filtered, nil
+}
+
+// VersionView returns a new Spec holding the document filtered by FilterVersion, it can be registered
+// and served alongside the original one.
+func (i *Spec) VersionView(version, basePath string) (*Spec, error) {
+	swagger, err := i.Swagger()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, err := FilterVersion(swagger, version, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpecView(filtered, i.InstanceName()+"_"+version), nil
+}
@@ -0,0 +1,111 @@
+package swag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersionComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Version v1, v2`, nil))
+	assert.Equal(t, []interface{}{"v1", "v2"}, operation.Extensions[versionsExtension])
+
+	assert.EqualError(t, NewOperation(nil).ParseComment(`/@Version ,`, nil), "@Version needs at least a version, eg: @Version v1,v2")
+}
+
+func TestFilterVersion(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string
+}
+
+type Order struct {
+	ID int
+}
+
+type Legacy struct {
+	ID int
+}
+
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser(){
+}
+
+// @Version v2
+// @Success 200 {array} Order
+// @Router /orders [get]
+func ListOrders(){
+}
+
+// @Version v1
+// @Success 200 {object} Legacy
+// @Router /legacy [get]
+func GetLegacy(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.swagger.Info.Version = "1.0"
+
+	v1, err := FilterVersion(p.swagger, "v1", "/api/v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1", v1.BasePath)
+	assert.Equal(t, "v1", v1.Info.Version)
+	assert.Len(t, v1.Paths.Paths, 2)
+	assert.Contains(t, v1.Paths.Paths, "/legacy")
+	assert.Equal(t, []interface{}{"v1"}, v1.Paths.Paths["/legacy"].Get.Extensions[versionsExtension])
+	assert.Contains(t, v1.Definitions, "api.User")
+	assert.Contains(t, v1.Definitions, "api.Legacy")
+	assert.NotContains(t, v1.Definitions, "api.Order")
+
+	v2, err := FilterVersion(p.swagger, "v2", "/api/v2")
+	assert.NoError(t, err)
+	assert.Len(t, v2.Paths.Paths, 2)
+	assert.Contains(t, v2.Paths.Paths, "/orders")
+	assert.Contains(t, v2.Definitions, "api.Order")
+	assert.NotContains(t, v2.Definitions, "api.Legacy")
+
+	assert.Equal(t, "1.0", p.swagger.Info.Version)
+	assert.Len(t, p.swagger.Paths.Paths, 3)
+}
+
+func TestSpec_VersionView(t *testing.T) {
+	t.Parallel()
+
+	doc := &Spec{InfoInstanceName: "swagger", SwaggerTemplate: testSpecTemplate}
+	assert.NoError(t, doc.Update(func(doc *spec.Swagger) error {
+		doc.Paths.Paths["/internal/metrics"].Get.AddExtension(versionsExtension, []interface{}{"v2"})
+
+		return nil
+	}))
+
+	view := Must(doc.VersionView("v1.1", "/api/v1.1"))
+	assert.Equal(t, "swagger_v1.1", view.InstanceName())
+	assert.Equal(t, "/api/v1.1", view.BasePath)
+	assert.Equal(t, "v1.1", view.Version)
+
+	swagger, err := view.Swagger()
+	assert.NoError(t, err)
+	assert.NotContains(t, swagger.Paths.Paths, "/internal/metrics")
+	assert.Contains(t, swagger.Paths.Paths, "/pets")
+
+	assert.Panics(t, func() {
+		Must(nil, errors.New("invalid"))
+	})
+}
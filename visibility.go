@@ -0,0 +1,205 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	visibilityExtension = "x-visibility"
+	internalExtension   = "x-internal"
+)
+
+const (
+	// VisibilityPublic operations and fields are visible to everyone, it is the default visibility.
+	VisibilityPublic = "public"
+
+	// VisibilityPartner operations and fields are visible to partners and internally.
+	VisibilityPartner = "partner"
+
+	// VisibilityInternal operations and fields are only visible internally.
+	VisibilityInternal = "internal"
+)
+
+// visibilityRanks orders visibilities, a variant shows everything with a rank lower than or equal to its own.
+var visibilityRanks = map[string]int{
+	VisibilityPublic:   0,
+	VisibilityPartner:  1,
+	VisibilityInternal: 2,
+}
+
+// ParseVisibilityComment parses comment for given `visibility` comment string, eg: @Visibility internal.
+func (operation *Operation) ParseVisibilityComment(commentLine string) error {
+	visibility := strings.ToLower(strings.TrimSpace(commentLine))
+	if _, ok := visibilityRanks[visibility]; !ok {
+		return fmt.Errorf("invalid visibility: %s", commentLine)
+	}
+
+	operation.Operation.AddExtension(visibilityExtension, visibility)
+
+	return nil
+}
+
+// FilterVisibility returns a copy of swagger with only the operations, parameters and properties
+// visible at given visibility, including the properties of the inline schemas of the operations,
+// and the webhooks and callbacks. Properties with the x-internal extension are internal.
+// Definitions which are no longer reachable once filtered are removed.
+func FilterVisibility(swagger *spec.Swagger, visibility string) (*spec.Swagger, error) {
+	rank, ok := visibilityRanks[visibility]
+	if !ok {
+		return nil, fmt.Errorf("invalid visibility: %s", visibility)
+	}
+
+	doc, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered spec.Swagger
+
+	err = json.Unmarshal(doc, &filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	reachable, err := reachableDefinitions(&filtered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keep func(op *spec.Operation) bool
+
+	keep = func(op *spec.Operation) bool {
+		if !isVisible(op.Extensions, rank) {
+			return false
+		}
+
+		var params []spec.Parameter
+
+		for _, param := range op.Parameters {
+			if isVisible(param.Extensions, rank) {
+				if param.Schema != nil {
+					filterProperties(param.Schema, rank)
+				}
+
+				params = append(params, param)
+			}
+		}
+
+		op.Parameters = params
+
+		if op.Responses != nil {
+			if op.Responses.Default != nil && op.Responses.Default.Schema != nil {
+				filterProperties(op.Responses.Default.Schema, rank)
+			}
+
+			for _, response := range op.Responses.StatusCodeResponses {
+				if response.Schema != nil {
+					filterProperties(response.Schema, rank)
+				}
+			}
+		}
+
+		if callbacksErr := filterCallbacks(op, keep); callbacksErr != nil && err == nil {
+			err = callbacksErr
+		}
+
+		return true
+	}
+
+	filterOperations(&filtered, keep)
+
+	if err == nil {
+		err = filterWebhooks(&filtered, keep)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, schema := range filtered.Definitions {
+		filterProperties(&schema, rank)
+		filtered.Definitions[name] = schema
+	}
+
+	stillReachable, err := reachableDefinitions(&filtered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range reachable {
+		if _, ok := stillReachable[name]; !ok {
+			delete(filtered.Definitions, name)
+		}
+	}
+
+	return &filtered, nil
+}
+
+func isVisible(extensions spec.Extensions, rank int) bool {
+	if internal, ok := extensions.GetBool(internalExtension); ok && internal {
+		return rank >= visibilityRanks[VisibilityInternal]
+	}
+
+	visibility, ok := extensions.GetString(visibilityExtension)
+	if !ok {
+		return true
+	}
+
+	return visibilityRanks[visibility] <= rank
+}
+
+// filterProperties removes the properties of schema, and of its nested schemas, which are not visible at rank.
+func filterProperties(schema *spec.Schema, rank int) {
+	for name, prop := range schema.Properties {
+		if !isVisible(prop.Extensions, rank) {
+			delete(schema.Properties, name)
+
+			var required []string
+
+			for _, field := range schema.Required {
+				if field != name {
+					required = append(required, field)
+				}
+			}
+
+			schema.Required = required
+
+			continue
+		}
+
+		filterProperties(&prop, rank)
+		schema.Properties[name] = prop
+	}
+
+	for i := range schema.AllOf {
+		filterProperties(&schema.AllOf[i], rank)
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		filterProperties(schema.Items.Schema, rank)
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		filterProperties(schema.AdditionalProperties.Schema, rank)
+	}
+}
+
+// View returns a new Spec holding the document filtered by FilterVisibility, it can be registered
+// and served alongside the original one.
+func (i *Spec) View(visibility string) (*Spec, error) {
+	swagger, err := i.Swagger()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, err := FilterVisibility(swagger, visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSpecView(filtered, i.InstanceName()+"_"+visibility), nil
+}
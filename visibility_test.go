@@ -0,0 +1,265 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVisibilityComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Visibility Internal`, nil))
+	assert.Equal(t, "internal", operation.Extensions[visibilityExtension])
+
+	assert.EqualError(t, NewOperation(nil).ParseComment(`/@Visibility secret`, nil), "invalid visibility: secret")
+}
+
+func TestFilterVisibility(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name    string ` + "`json:\"name\" binding:\"required\"`" + `
+	Email   string ` + "`json:\"email\" binding:\"required\" visibility:\"partner\"`" + `
+	Token   string ` + "`json:\"token\" extensions:\"x-internal\"`" + `
+	Account Account ` + "`json:\"account\" visibility:\"internal\"`" + `
+}
+
+type Account struct {
+	ID int
+}
+
+type Metrics struct {
+	Requests int
+}
+
+// @Param verbose query bool false "verbose"
+// @Success 200 {object} User
+// @Router /users/{id} [get]
+func GetUser(){
+}
+
+// @Visibility internal
+// @Success 200 {object} Metrics
+// @Router /metrics [get]
+func GetMetrics(){
+}
+
+// @Visibility partner
+// @Success 200 {object} User
+// @Router /users [get]
+func ListUsers(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	public, err := FilterVisibility(p.swagger, VisibilityPublic)
+	assert.NoError(t, err)
+	assert.Len(t, public.Paths.Paths, 1)
+	assert.Contains(t, public.Paths.Paths, "/users/{id}")
+	assert.Len(t, public.Paths.Paths["/users/{id}"].Get.Parameters, 1)
+	assert.NotContains(t, public.Definitions, "api.Metrics")
+	assert.NotContains(t, public.Definitions, "api.Account")
+
+	user := public.Definitions["api.User"]
+	assert.Contains(t, user.Properties, "name")
+	assert.NotContains(t, user.Properties, "email")
+	assert.NotContains(t, user.Properties, "token")
+	assert.Equal(t, []string{"name"}, user.Required)
+
+	partner, err := FilterVisibility(p.swagger, VisibilityPartner)
+	assert.NoError(t, err)
+	assert.Len(t, partner.Paths.Paths, 2)
+	assert.Contains(t, partner.Definitions["api.User"].Properties, "email")
+	assert.NotContains(t, partner.Definitions["api.User"].Properties, "token")
+
+	internal, err := FilterVisibility(p.swagger, VisibilityInternal)
+	assert.NoError(t, err)
+	assert.Len(t, internal.Paths.Paths, 3)
+	assert.Len(t, internal.Definitions, 3)
+	assert.Contains(t, internal.Definitions["api.User"].Properties, "token")
+
+	// the original document is left unchanged.
+	assert.Len(t, p.swagger.Paths.Paths, 3)
+	assert.Contains(t, p.swagger.Definitions["api.User"].Properties, "email")
+
+	_, err = FilterVisibility(p.swagger, "secret")
+	assert.EqualError(t, err, "invalid visibility: secret")
+}
+
+func TestFilterVisibility_InlineSchemas(t *testing.T) {
+	t.Parallel()
+
+	internal := spec.StringProperty()
+	internal.AddExtension(visibilityExtension, VisibilityInternal)
+
+	// an inline response struct, with an inline struct property
+	owner := spec.Schema{}
+	owner.Typed(OBJECT, "")
+	owner.SetProperty("login", *spec.StringProperty())
+	owner.SetProperty("email", *internal)
+
+	response := spec.Schema{}
+	response.Typed(OBJECT, "")
+	response.SetProperty("name", *spec.StringProperty())
+	response.SetProperty("secret", *internal)
+	response.SetProperty("owner", owner)
+	response.Required = []string{"name", "secret"}
+
+	op := spec.NewOperation("getUser")
+	op.RespondsWith(200, spec.NewResponse().WithSchema(&response))
+	op.AddParam(spec.BodyParam("user", &response))
+
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/users": {PathItemProps: spec.PathItemProps{Post: op}},
+		}},
+	}}
+
+	public, err := FilterVisibility(swagger, VisibilityPublic)
+	require.NoError(t, err)
+
+	publicOp := public.Paths.Paths["/users"].Post
+
+	for _, schema := range []*spec.Schema{
+		publicOp.Responses.StatusCodeResponses[200].Schema,
+		publicOp.Parameters[0].Schema,
+	} {
+		assert.Contains(t, schema.Properties, "name")
+		assert.NotContains(t, schema.Properties, "secret")
+		assert.Equal(t, []string{"name"}, schema.Required)
+		assert.Contains(t, schema.Properties["owner"].Properties, "login")
+		assert.NotContains(t, schema.Properties["owner"].Properties, "email")
+	}
+
+	all, err := FilterVisibility(swagger, VisibilityInternal)
+	require.NoError(t, err)
+	assert.Contains(t, all.Paths.Paths["/users"].Post.Responses.StatusCodeResponses[200].Schema.Properties, "secret")
+	assert.Contains(t, all.Paths.Paths["/users"].Post.Parameters[0].Schema.Properties["owner"].Properties, "email")
+}
+
+func TestFilterVisibility_WebhooksAndCallbacks(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	Name string
+}
+
+type AuditEvent struct {
+	ID string
+}
+
+type PaymentEvent struct {
+	ID string
+}
+
+// @Param pet body Pet true "the new pet"
+// @Success 200
+// @Webhook newPet [post]
+
+// @Visibility internal
+// @Param event body AuditEvent true "audit event"
+// @Success 200
+// @Webhook audit [post]
+
+// @Visibility internal
+// @Param event body PaymentEvent true "payment event"
+// @Success 204
+// @Callback onPaid {$request.body#/callbackUrl} [post]
+
+// @Success 204
+// @Callback onPaid {$request.body#/callbackUrl} [get]
+
+// @Callback onPaid
+// @Success 202
+// @Router /payments [post]
+func Pay(){
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.parseWebhooksAndCallbacks))
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	public, err := FilterVisibility(p.swagger, VisibilityPublic)
+	require.NoError(t, err)
+
+	var webhooks map[string]spec.PathItem
+
+	ok, err := decodeExtension(public.Extensions, webhooksExtension, &webhooks)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Contains(t, webhooks, "newPet")
+	assert.NotContains(t, webhooks, "audit")
+
+	var callbacks map[string]map[string]spec.PathItem
+
+	ok, err = decodeExtension(public.Paths.Paths["/payments"].Post.Extensions, callbacksExtension, &callbacks)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	onPaid := callbacks["onPaid"]["{$request.body#/callbackUrl}"]
+	assert.Nil(t, onPaid.Post)
+	assert.NotNil(t, onPaid.Get)
+
+	// the definitions of the internal webhook and callback are pruned
+	assert.Contains(t, public.Definitions, "api.Pet")
+	assert.NotContains(t, public.Definitions, "api.AuditEvent")
+	assert.NotContains(t, public.Definitions, "api.PaymentEvent")
+
+	internal, err := FilterVisibility(p.swagger, VisibilityInternal)
+	require.NoError(t, err)
+	assert.Contains(t, internal.Definitions, "api.AuditEvent")
+	assert.Contains(t, internal.Definitions, "api.PaymentEvent")
+
+	// a callback without visible operations is removed
+	parsedCallbacks := p.swagger.Paths.Paths["/payments"].Post.Extensions[callbacksExtension].(map[string]map[string]spec.PathItem)
+	parsedCallbacks["onPaid"]["{$request.body#/callbackUrl}"].Get.AddExtension(visibilityExtension, VisibilityPartner)
+
+	public, err = FilterVisibility(p.swagger, VisibilityPublic)
+	require.NoError(t, err)
+	assert.NotContains(t, public.Paths.Paths["/payments"].Post.Extensions, callbacksExtension)
+}
+
+func TestSpec_View(t *testing.T) {
+	t.Parallel()
+
+	doc := &Spec{InfoInstanceName: "swagger", SwaggerTemplate: testSpecTemplate}
+	assert.NoError(t, doc.Update(func(doc *spec.Swagger) error {
+		doc.Paths.Paths["/internal/metrics"].Get.AddExtension(visibilityExtension, VisibilityInternal)
+
+		return nil
+	}))
+
+	view, err := doc.View(VisibilityPublic)
+	assert.NoError(t, err)
+	assert.Equal(t, "swagger_public", view.InstanceName())
+
+	swagger, err := view.Swagger()
+	assert.NoError(t, err)
+	assert.NotContains(t, swagger.Paths.Paths, "/internal/metrics")
+	assert.Contains(t, swagger.Paths.Paths, "/pets")
+
+	swagger, err = doc.Swagger()
+	assert.NoError(t, err)
+	assert.Contains(t, swagger.Paths.Paths, "/internal/metrics")
+}
@@ -0,0 +1,316 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	webhooksExtension  = "x-webhooks"
+	callbacksExtension = "x-callbacks"
+)
+
+// WebhookProperties describes a single `@Webhook name [method]` comment.
+type WebhookProperties struct {
+	Name       string
+	HTTPMethod string
+}
+
+// CallbackProperties describes a single `@Callback name [{expression} [method]]` comment.
+// A callback with an expression is a definition, one without is a reference to a definition.
+type CallbackProperties struct {
+	Name       string
+	Expression string
+	HTTPMethod string
+}
+
+// IsDefinition whether the callback defines a request instead of referencing one.
+func (c CallbackProperties) IsDefinition() bool {
+	return c.Expression != ""
+}
+
+var (
+	webhookPattern  = regexp.MustCompile(`^([\w.\-]+)[[:blank:]]+\[(\w+)]$`)
+	callbackPattern = regexp.MustCompile(`^([\w.\-]+)(?:[[:blank:]]+(\{\S+})[[:blank:]]+\[(\w+)])?$`)
+)
+
+// ParseWebhookComment parses comment for given `webhook` comment string, eg: @Webhook newPet [post].
+func (operation *Operation) ParseWebhookComment(commentLine string) error {
+	matches := webhookPattern.FindStringSubmatch(strings.TrimSpace(commentLine))
+	if len(matches) != 3 {
+		return fmt.Errorf("can not parse webhook comment \"%s\"", commentLine)
+	}
+
+	webhook := WebhookProperties{
+		Name:       matches[1],
+		HTTPMethod: strings.ToUpper(matches[2]),
+	}
+
+	if _, ok := allMethod[webhook.HTTPMethod]; !ok {
+		return fmt.Errorf("invalid method: %s", webhook.HTTPMethod)
+	}
+
+	operation.Webhooks = append(operation.Webhooks, webhook)
+
+	return nil
+}
+
+// ParseCallbackComment parses comment for given `callback` comment string,
+// eg: @Callback onPaid {$request.body#/callbackUrl} [post] to define a callback,
+// or @Callback onPaid to reference it from an operation.
+func (operation *Operation) ParseCallbackComment(commentLine string) error {
+	matches := callbackPattern.FindStringSubmatch(strings.TrimSpace(commentLine))
+	if len(matches) != 4 {
+		return fmt.Errorf("can not parse callback comment \"%s\"", commentLine)
+	}
+
+	callback := CallbackProperties{
+		Name:       matches[1],
+		Expression: matches[2],
+		HTTPMethod: strings.ToUpper(matches[3]),
+	}
+
+	if callback.IsDefinition() {
+		if _, ok := allMethod[callback.HTTPMethod]; !ok {
+			return fmt.Errorf("invalid method: %s", callback.HTTPMethod)
+		}
+	}
+
+	operation.Callbacks = append(operation.Callbacks, callback)
+
+	return nil
+}
+
+// isWebhookOrCallbackComment whether comments define a webhook or a callback instead of an operation.
+func isWebhookOrCallbackComment(comments []*ast.Comment) bool {
+	for _, comment := range comments {
+		commentLine := strings.TrimSpace(strings.TrimLeft(comment.Text, "/"))
+		if len(commentLine) == 0 {
+			continue
+		}
+
+		fields := FieldsByAnySpace(commentLine, 2)
+		switch strings.ToLower(fields[0]) {
+		case webhookAttr:
+			return true
+		case callbackAttr:
+			if len(fields) > 1 && strings.Contains(fields[1], "{") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseWebhooksAndCallbacks collects the webhook and callback definitions declared in the comments of given file.
+func (parser *Parser) parseWebhooksAndCallbacks(fileInfo *AstFileInfo) error {
+	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
+		return nil
+	}
+
+	for _, commentGroup := range fileInfo.File.Comments {
+		comments := commentGroup.List
+		if !isWebhookOrCallbackComment(comments) || isOperationDefaultsComment(comments) {
+			continue
+		}
+
+		if !parser.matchTags(comments) || !matchExtension(parser.parseExtension, comments) {
+			continue
+		}
+
+		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+		for _, comment := range comments {
+			err := operation.ParseComment(comment.Text, fileInfo.File)
+			if err != nil {
+				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
+			}
+		}
+
+		if operation.State != "" && operation.State != parser.HostState {
+			continue
+		}
+
+		if len(operation.RouterProperties) > 0 {
+			return fmt.Errorf("webhook or callback in file %s can not declare %s", fileInfo.Path, routerAttr)
+		}
+
+		parser.applyOperationDefaults(operation, fileInfo.PackagePath)
+
+		for _, webhook := range operation.Webhooks {
+			err := parser.addWebhook(webhook, operation)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, callback := range operation.Callbacks {
+			if !callback.IsDefinition() {
+				return fmt.Errorf("callback %s in file %s can not reference another callback", callback.Name, fileInfo.Path)
+			}
+
+			err := parser.addCallback(callback, operation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (parser *Parser) addWebhook(webhook WebhookProperties, operation *Operation) error {
+	if parser.swagger.Extensions == nil {
+		parser.swagger.Extensions = make(spec.Extensions)
+	}
+
+	webhooks, ok := parser.swagger.Extensions[webhooksExtension].(map[string]spec.PathItem)
+	if !ok {
+		webhooks = make(map[string]spec.PathItem)
+		parser.swagger.Extensions[webhooksExtension] = webhooks
+	}
+
+	pathItem := webhooks[webhook.Name]
+
+	op := refRouteMethodOp(&pathItem, webhook.HTTPMethod)
+	if *op != nil {
+		err := fmt.Errorf("webhook %s %s is declared multiple times", webhook.HTTPMethod, webhook.Name)
+		if parser.Strict {
+			return err
+		}
+
+		parser.debug.Printf("warning: %s\n", err)
+	}
+
+	newOp := operation.Operation
+	*op = &newOp
+
+	webhooks[webhook.Name] = pathItem
+
+	return nil
+}
+
+func (parser *Parser) addCallback(callback CallbackProperties, operation *Operation) error {
+	if parser.callbacks == nil {
+		parser.callbacks = make(map[string]map[string]spec.PathItem)
+	}
+
+	expressions, ok := parser.callbacks[callback.Name]
+	if !ok {
+		expressions = make(map[string]spec.PathItem)
+		parser.callbacks[callback.Name] = expressions
+	}
+
+	pathItem := expressions[callback.Expression]
+
+	op := refRouteMethodOp(&pathItem, callback.HTTPMethod)
+	if *op != nil {
+		err := fmt.Errorf("callback %s %s %s is declared multiple times", callback.Name, callback.Expression, callback.HTTPMethod)
+		if parser.Strict {
+			return err
+		}
+
+		parser.debug.Printf("warning: %s\n", err)
+	}
+
+	newOp := operation.Operation
+	*op = &newOp
+
+	expressions[callback.Expression] = pathItem
+
+	return nil
+}
+
+// applyCallbacks resolves the callbacks referenced by operation into its x-callbacks extension.
+func (parser *Parser) applyCallbacks(operation *Operation) error {
+	if len(operation.Callbacks) == 0 {
+		return nil
+	}
+
+	callbacks := make(map[string]map[string]spec.PathItem)
+
+	for _, callback := range operation.Callbacks {
+		if callback.IsDefinition() {
+			return fmt.Errorf("callback %s must be defined in its own comment block, reference it with %s %s", callback.Name, callbackAttr, callback.Name)
+		}
+
+		expressions, ok := parser.callbacks[callback.Name]
+		if !ok {
+			return fmt.Errorf("callback %s is not defined", callback.Name)
+		}
+
+		callbacks[callback.Name] = expressions
+	}
+
+	operation.Extensions[callbacksExtension] = callbacks
+
+	return nil
+}
+
+// filterWebhooks removes the webhook operations of doc for which keep returns false, and the webhooks without
+// operations left.
+func filterWebhooks(doc *spec.Swagger, keep func(op *spec.Operation) bool) error {
+	var webhooks map[string]spec.PathItem
+
+	ok, err := decodeExtension(doc.Extensions, webhooksExtension, &webhooks)
+	if err != nil || !ok {
+		return err
+	}
+
+	filterPathItems(webhooks, keep)
+
+	if len(webhooks) == 0 {
+		delete(doc.Extensions, webhooksExtension)
+	} else {
+		doc.Extensions[webhooksExtension] = webhooks
+	}
+
+	return nil
+}
+
+// filterCallbacks removes the callback operations of op for which keep returns false, and the callbacks without
+// operations left.
+func filterCallbacks(op *spec.Operation, keep func(op *spec.Operation) bool) error {
+	var callbacks map[string]map[string]spec.PathItem
+
+	ok, err := decodeExtension(op.Extensions, callbacksExtension, &callbacks)
+	if err != nil || !ok {
+		return err
+	}
+
+	for name, expressions := range callbacks {
+		filterPathItems(expressions, keep)
+
+		if len(expressions) == 0 {
+			delete(callbacks, name)
+		}
+	}
+
+	if len(callbacks) == 0 {
+		delete(op.Extensions, callbacksExtension)
+	} else {
+		op.Extensions[callbacksExtension] = callbacks
+	}
+
+	return nil
+}
+
+// decodeExtension decodes the extension of key into target, and reports whether there is one.
+func decodeExtension(extensions spec.Extensions, key string, target interface{}) (bool, error) {
+	value, ok := extensions[key]
+	if !ok {
+		return false, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(b, target)
+}
@@ -0,0 +1,138 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWebhookComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Webhook newPet [post]`, nil))
+	assert.Equal(t, []WebhookProperties{{Name: "newPet", HTTPMethod: "POST"}}, operation.Webhooks)
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Webhook newPet`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Webhook newPet [unknown]`, nil))
+}
+
+func TestParseCallbackComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	assert.NoError(t, operation.ParseComment(`/@Callback onPaid {$request.body#/callbackUrl} [post]`, nil))
+	assert.NoError(t, operation.ParseComment(`/@Callback onRefund`, nil))
+	assert.Equal(t, []CallbackProperties{
+		{Name: "onPaid", Expression: "{$request.body#/callbackUrl}", HTTPMethod: "POST"},
+		{Name: "onRefund"},
+	}, operation.Callbacks)
+	assert.True(t, operation.Callbacks[0].IsDefinition())
+	assert.False(t, operation.Callbacks[1].IsDefinition())
+
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Callback onPaid {$request.body#/callbackUrl}`, nil))
+	assert.Error(t, NewOperation(nil).ParseComment(`/@Callback onPaid {$request.body#/callbackUrl} [unknown]`, nil))
+}
+
+func TestParser_WebhooksAndCallbacks(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	Name string
+}
+
+type PaymentEvent struct {
+	ID string
+}
+
+// @Summary A pet was added
+// @Tags pet
+// @Param pet body Pet true "the new pet"
+// @Success 200 "received"
+// @Webhook newPet [post]
+
+// @Summary Payment result
+// @Param event body PaymentEvent true "payment event"
+// @Success 204
+// @Callback onPaid {$request.body#/callbackUrl} [post]
+
+// @Summary Pay
+// @Callback onPaid
+// @Success 202
+// @Router /payments [post]
+func Pay(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.packages.RangeFiles(p.parseWebhooksAndCallbacks))
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	assert.Len(t, p.swagger.Paths.Paths, 1)
+
+	webhooks := p.swagger.Extensions[webhooksExtension].(map[string]spec.PathItem)
+	assert.NotNil(t, webhooks["newPet"].Post)
+	assert.Equal(t, "A pet was added", webhooks["newPet"].Post.Summary)
+	assert.Equal(t, "pet", webhooks["newPet"].Post.Parameters[0].Name)
+
+	pay := p.swagger.Paths.Paths["/payments"].Post
+	callbacks := pay.Extensions[callbacksExtension].(map[string]map[string]spec.PathItem)
+	onPaid := callbacks["onPaid"]["{$request.body#/callbackUrl}"].Post
+	assert.NotNil(t, onPaid)
+	assert.Equal(t, "Payment result", onPaid.Summary)
+	assert.Contains(t, onPaid.Responses.StatusCodeResponses, 204)
+
+	b, err := json.Marshal(p.swagger)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"x-webhooks":{"newPet":{"post":`)
+	assert.Contains(t, string(b), `"x-callbacks":{"onPaid":{"{$request.body#/callbackUrl}":{"post":`)
+}
+
+func TestParser_CallbackErr(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Callback missing
+// @Router /payments [post]
+func Pay(){
+}
+`
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.NoError(t, p.packages.RangeFiles(p.parseWebhooksAndCallbacks))
+	assert.EqualError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo), "callback missing is not defined")
+
+	src = `
+package api
+
+// @Webhook newPet [post]
+// @Router /pets [post]
+func Pet(){
+}
+`
+	p = New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.Error(t, p.packages.RangeFiles(p.parseWebhooksAndCallbacks))
+
+	src = `
+package api
+
+// @Webhook newPet [post]
+
+// @Webhook newPet [post]
+`
+	p = New(SetStrict(true))
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	assert.EqualError(t, p.packages.RangeFiles(p.parseWebhooksAndCallbacks), "webhook POST newPet is declared multiple times")
+}